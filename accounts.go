@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"wppanalyticscli/internal/api"
+	"wppanalyticscli/internal/config"
+	"wppanalyticscli/internal/formatter"
+)
+
+// runAccountsReport fetches every account of a multi-account report with a
+// bounded worker pool, then prints a section per account and a consolidated
+// summary. Failed accounts are reported without dropping the others; the exit
+// code is 1 when any account failed. Nothing is printed when the run is cancelled.
+func runAccountsReport(ctx context.Context, apiClient api.Client, cfg *config.Config, numberFormat formatter.NumberFormat, start, end int64, loc *time.Location) int {
+	results := fetchAccounts(cfg.Accounts, cfg.Concurrency, func(wbaID string) formatter.AccountResult {
+		accountCfg := *cfg
+		accountCfg.WBAID = wbaID
+
+		result := formatter.AccountResult{WBAID: wbaID}
+		if cfg.Mode == "template" {
+			result.Templates, result.Err = fetchTemplateAnalytics(ctx, apiClient, &accountCfg, start, end, loc)
+			if result.Err == nil {
+				result.Currency = resolveCurrency(ctx, apiClient, &accountCfg)
+			}
+		} else {
+			result.Analytics, result.Err = fetchAnalytics(ctx, apiClient, &accountCfg, start, end, loc)
+		}
+		return result
+	})
+
+	if err := ctx.Err(); err != nil {
+		return failureStatus(ctx, "Error", err)
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+			continue
+		}
+
+		var report string
+		switch {
+		case result.Templates != nil && cfg.View == "funnel":
+			report = textFunnelReport(cfg, numberFormat, result.Templates, start, end, loc)
+		case result.Templates != nil:
+			report = textTemplateReport(cfg, numberFormat, result.Currency, result.Templates, start, end, loc)
+		default:
+			report = textAnalyticsReport(cfg, numberFormat, result.Analytics, start, end, loc)
+		}
+		printReport(cfg, fmt.Sprintf("🏢 Account %s\n\n%s\n", result.WBAID, report))
+	}
+
+	summary := formatter.NewAccountsFormatter()
+	summary.SetWindow(start, end)
+	applyTextOptions(summary, cfg, numberFormat)
+	if cfg.Mode == "template" {
+		printReport(cfg, summary.FormatTemplate(results, loc))
+	} else {
+		printReport(cfg, summary.Format(results, loc))
+	}
+
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, "Error: %d of %d accounts failed\n", failed, len(results))
+		return 1
+	}
+	return 0
+}
+
+// fetchAccounts calls fetch for every WABA with at most concurrency calls in
+// flight, returning the results in the order of wbaIDs
+func fetchAccounts(wbaIDs []string, concurrency int, fetch func(wbaID string) formatter.AccountResult) []formatter.AccountResult {
+	results := make([]formatter.AccountResult, len(wbaIDs))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < min(concurrency, len(wbaIDs)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = fetch(wbaIDs[i])
+			}
+		}()
+	}
+
+	for i := range wbaIDs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"wppanalyticscli/internal/api"
+	"wppanalyticscli/internal/cache"
+	"wppanalyticscli/internal/config"
+)
+
+// defaultCacheTTL is how long responses for windows that include the present are reused
+const defaultCacheTTL = 10 * time.Minute
+
+// runCacheCommand handles "cache <subcommand>" and returns the process exit code
+func runCacheCommand(args []string) int {
+	if len(args) == 0 || (args[0] != "clear" && args[0] != "stats") {
+		fmt.Fprintf(os.Stderr, "Usage: %s cache clear|stats\n", os.Args[0])
+		return 1
+	}
+
+	flags := flag.NewFlagSet("cache "+args[0], flag.ExitOnError)
+	var timezone = flags.String("timezone", "America/Sao_Paulo", "Timezone for date display")
+	flags.Parse(args[1:])
+
+	dir, err := cache.DefaultDir()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	store := cache.NewStore(dir)
+
+	if args[0] == "clear" {
+		removed, err := store.Clear()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error clearing cache: %v\n", err)
+			return 1
+		}
+		fmt.Printf("🧹 Removed %d cached responses from %s\n", removed, dir)
+		return 0
+	}
+
+	stats, err := store.Stats()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading cache: %v\n", err)
+		return 1
+	}
+
+	loc := loadLocation(*timezone)
+	fmt.Printf("🗄️  Cache Directory: %s\n", stats.Dir)
+	fmt.Printf("📦 Entries: %d (%d expired)\n", stats.Entries, stats.Expired)
+	fmt.Printf("💾 Size: %s\n", formatBytes(stats.Bytes))
+	if !stats.Oldest.IsZero() {
+		fmt.Printf("⏳ Oldest: %s\n", stats.Oldest.In(loc).Format("2006-01-02 15:04:05 MST"))
+		fmt.Printf("🕐 Newest: %s\n", stats.Newest.In(loc).Format("2006-01-02 15:04:05 MST"))
+	}
+	return 0
+}
+
+// newCachedClient wraps the API client with the on-disk response cache, falling
+// back to the uncached client when the cache directory cannot be located
+func newCachedClient(apiClient api.Client, cfg *config.Config, loc *time.Location, debug bool) api.Client {
+	dir, err := cache.DefaultDir()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v, responses will not be cached\n", err)
+		return apiClient
+	}
+
+	cachedClient := cache.NewClient(apiClient, cache.NewStore(dir), cfg.CacheTTL)
+	cachedClient.SetRefresh(cfg.Refresh)
+	cachedClient.SetLocation(loc)
+	if cfg.APIBaseURL != api.DefaultBaseURL || cfg.APIVersion != api.DefaultVersion {
+		cachedClient.SetScope(cfg.APIBaseURL + "/" + cfg.APIVersion)
+	}
+	if debug {
+		cachedClient.SetDebugOutput(os.Stderr)
+	}
+	return cachedClient
+}
+
+// formatBytes formats a size in bytes with a binary unit
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for value := n / unit; value >= unit; value /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
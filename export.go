@@ -0,0 +1,215 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"wppanalyticscli/internal/api"
+	"wppanalyticscli/internal/config"
+	"wppanalyticscli/internal/datetime"
+	"wppanalyticscli/internal/export"
+	"wppanalyticscli/internal/models"
+)
+
+// exportTemplateBatch is how many template IDs one template analytics request carries
+const exportTemplateBatch = 10
+
+// exportMetrics are the template metrics written by the export
+var exportMetrics = []string{"sent", "delivered", "read", "clicked", "cost"}
+
+// runExportCommand handles "export <format>" and returns the process exit code
+func runExportCommand(args []string) int {
+	if len(args) == 0 || args[0] != "sqlite" {
+		fmt.Fprintf(os.Stderr, "Usage: %s export sqlite -db=<file> -wbaid=<id> [-start=-30d] [-end=today] [-templates=<ids>]\n", os.Args[0])
+		return 1
+	}
+
+	flags := flag.NewFlagSet("export sqlite", flag.ExitOnError)
+	var dbPath = flags.String("db", "", "SQLite database to create or update (required)")
+	var wbaID = flags.String("wbaid", "", "WBA ID (required)")
+	var startDate = flags.String("start", "-30d", "Start date: YYYY-MM-DD or relative (-30d, yesterday)")
+	var endDate = flags.String("end", "today", "End date (exclusive): YYYY-MM-DD or relative (today)")
+	var templateIDs = flags.String("templates", "", "Comma-separated template IDs to export analytics for (default: every template of the WABA)")
+	var timezone = flags.String("timezone", "America/Sao_Paulo", "Timezone of day boundaries and the day column")
+	var debug = flags.Bool("debug", false, "Print API requests to stderr (access token is redacted)")
+	var timeout = flags.Duration("timeout", 0, "Limit for the whole run, e.g. 10m (0 for no limit)")
+	var requestTimeout = flags.Duration("request-timeout", api.DefaultRequestTimeout, "Limit for each API request (0 for no limit)")
+	var apiBaseURL, apiVersion = apiEndpointFlags(flags)
+	flags.Parse(args[1:])
+
+	if *dbPath == "" || *wbaID == "" {
+		fmt.Fprintf(os.Stderr, "Error: -db and -wbaid are required\n")
+		return 1
+	}
+
+	baseURL, version, err := resolveAPIEndpoint(*apiBaseURL, *apiVersion)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	loc := loadLocation(*timezone)
+	parser := datetime.NewISO8601ParserInLocation(loc)
+	start, err := parser.ParseToEpoch(*startDate)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing start date: %v\n", err)
+		return 1
+	}
+	end, err := parser.ParseToEpoch(*endDate)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing end date: %v\n", err)
+		return 1
+	}
+	if start >= end {
+		fmt.Fprintf(os.Stderr, "Error: start date must be before end date\n")
+		return 1
+	}
+	if time.Since(time.Unix(start, 0)) > config.MaxLookback {
+		fmt.Fprintf(os.Stderr, "Error: start date is more than %d days ago, beyond what the API retains\n", int(config.MaxLookback.Hours()/24))
+		return 1
+	}
+
+	accessToken, err := loadAccessToken()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading access token: %v\n", err)
+		return 1
+	}
+
+	writer, err := export.OpenSQLite(*dbPath, loc)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	defer writer.Close()
+
+	ctx, cancel := runContext(*timeout)
+	defer cancel()
+
+	apiClient := newAPIClient(config.LoadAppSecret(), *debug, *requestTimeout, baseURL, version)
+	if err := exportSQLite(ctx, apiClient, writer, *wbaID, accessToken, start, end, splitList(*templateIDs), loc); err != nil {
+		return failureStatus(ctx, "Error", err)
+	}
+	return 0
+}
+
+// exportSQLite writes template metadata, daily WABA analytics and daily template
+// analytics for [start, end) and prints a summary
+func exportSQLite(ctx context.Context, apiClient api.Client, writer *export.SQLiteWriter, wbaID, accessToken string, start, end int64, templateIDs []string, loc *time.Location) error {
+	day := func(epoch int64) string { return time.Unix(epoch, 0).In(loc).Format("2006-01-02") }
+
+	templates, err := listAllTemplates(ctx, apiClient, wbaID, accessToken)
+	if err != nil {
+		return fmt.Errorf("listing templates: %w", err)
+	}
+	templateCount, err := writer.WriteTemplates(wbaID, templates)
+	if err != nil {
+		return err
+	}
+	if len(templateIDs) == 0 {
+		for _, template := range templates {
+			templateIDs = append(templateIDs, template.ID)
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Export: analytics %s..%s\n", day(start), day(end))
+	analytics, err := apiClient.GetAnalytics(ctx, wbaID, start, end, "DAY", accessToken)
+	if err != nil {
+		return fmt.Errorf("fetching analytics: %w", err)
+	}
+	analyticsCount, err := writer.WriteAnalytics(wbaID, analytics.Analytics.DataPoints)
+	if err != nil {
+		return err
+	}
+
+	// The template endpoint limits both the window and the number of IDs per request
+	pointCount := 0
+	span := int64(config.MaxTemplateSpan.Seconds())
+	for chunkStart := start; chunkStart < end; chunkStart += span {
+		chunkEnd := chunkStart + span
+		if chunkEnd > end {
+			chunkEnd = end
+		}
+		for i := 0; i < len(templateIDs); i += exportTemplateBatch {
+			batch := templateIDs[i:min(i+exportTemplateBatch, len(templateIDs))]
+			fmt.Fprintf(os.Stderr, "Export: templates %s %s..%s\n", strings.Join(batch, ","), day(chunkStart), day(chunkEnd))
+			response, err := apiClient.GetTemplateAnalytics(ctx, wbaID, chunkStart, chunkEnd, "DAILY", exportMetrics, batch, accessToken)
+			if err != nil {
+				return fmt.Errorf("fetching template analytics: %w", err)
+			}
+			for _, data := range response.Data {
+				n, err := writer.WriteTemplatePoints(wbaID, data.DataPoints)
+				if err != nil {
+					return err
+				}
+				pointCount += n
+			}
+		}
+	}
+
+	fmt.Printf("🗃️  Exported WhatsApp Business Account %s\n", wbaID)
+	fmt.Printf("📋 Templates: %d\n", templateCount)
+	fmt.Printf("📊 Analytics data points: %d\n", analyticsCount)
+	fmt.Printf("📈 Template data points: %d\n", pointCount)
+	return nil
+}
+
+// listAllTemplates lists every template of the WABA, following pagination
+func listAllTemplates(ctx context.Context, apiClient api.Client, wbaID, accessToken string) ([]models.MessageTemplate, error) {
+	const pageSize = 100
+
+	var templates []models.MessageTemplate
+	after := ""
+	for {
+		page, err := apiClient.ListTemplates(ctx, wbaID, accessToken, pageSize, after)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, page.Data...)
+
+		next := ""
+		if page.Paging != nil && page.Paging.Cursors != nil {
+			next = page.Paging.Cursors.After
+		}
+		if len(page.Data) < pageSize || next == "" || next == after {
+			return templates, nil
+		}
+		after = next
+	}
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries
+func splitList(value string) []string {
+	var values []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	return values
+}
+
+// writeParquetFile writes a Parquet report to -out and confirms it on stdout
+func writeParquetFile(cfg *config.Config, write func(w io.Writer) (int, error)) {
+	file, err := os.Create(cfg.OutFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	rows, err := write(file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(cfg.OutFile)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	printReport(cfg, fmt.Sprintf("💾 Wrote %d rows to %s\n", rows, cfg.OutFile))
+}
@@ -2,6 +2,25 @@ module wppanalyticscli
 
 go 1.24.1
 
-require golang.org/x/term v0.32.0
+require (
+	github.com/parquet-go/parquet-go v0.25.1
+	go.etcd.io/bbolt v1.3.10
+	golang.org/x/term v0.32.0
+	modernc.org/sqlite v1.37.1
+)
 
-require golang.org/x/sys v0.33.0 // indirect
+require (
+	github.com/andybalholm/brotli v1.1.0 // indirect
+	github.com/dustin/go-humanize v1.0.1 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/klauspost/compress v1.17.9 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/ncruces/go-strftime v0.1.9 // indirect
+	github.com/pierrec/lz4/v4 v4.1.21 // indirect
+	github.com/remyoudompheng/bigfft v0.0.0-20230129092748-24d4a6f8daec // indirect
+	golang.org/x/exp v0.0.0-20250408133849-7e4ce0ab07d0 // indirect
+	golang.org/x/sys v0.33.0 // indirect
+	modernc.org/libc v1.65.7 // indirect
+	modernc.org/mathutil v1.7.1 // indirect
+	modernc.org/memory v1.11.0 // indirect
+)
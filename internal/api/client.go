@@ -1,72 +1,153 @@
 package api
 
 import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"wppanalyticscli/internal/models"
 )
 
-// Client defines the interface for API operations
+// DefaultRequestTimeout bounds a single request, so a hung connection cannot block forever
+const DefaultRequestTimeout = 30 * time.Second
+
+// Client defines the interface for API operations. Every method stops when ctx
+// is cancelled.
 type Client interface {
-	GetAnalytics(wbaID string, start, end int64, granularity, accessToken string) (*models.AnalyticsResponse, error)
-	GetTemplateAnalytics(wbaID string, start, end int64, granularity string, metricTypes []string, templateIDs []string, accessToken string) (*models.TemplateAnalyticsResponse, error)
-	ListTemplates(wbaID string, accessToken string, limit int, after string) (*models.TemplateListResponse, error)
+	GetAnalytics(ctx context.Context, wbaID string, start, end int64, granularity, accessToken string) (*models.AnalyticsResponse, error)
+	GetTemplateAnalytics(ctx context.Context, wbaID string, start, end int64, granularity string, metricTypes []string, templateIDs []string, accessToken string) (*models.TemplateAnalyticsResponse, error)
+	ListTemplates(ctx context.Context, wbaID string, accessToken string, limit int, after string) (*models.TemplateListResponse, error)
+	DebugToken(ctx context.Context, accessToken string) (*models.TokenDebugResponse, error)
+	GetBusinessAccount(ctx context.Context, wbaID string, accessToken string) (*models.BusinessAccount, error)
 }
 
 // FacebookGraphClient implements the Client interface for Facebook Graph API
 type FacebookGraphClient struct {
-	httpClient *http.Client
-	baseURL    string
+	httpClient     *http.Client
+	baseURL        string // API root including the version, e.g. https://graph.facebook.com/v23.0
+	appSecret      string
+	debug          io.Writer
+	requestTimeout time.Duration
+}
+
+// Option configures a FacebookGraphClient built by NewFacebookGraphClient
+type Option func(*graphOptions)
+
+// graphOptions collects the options before the client is built, since the
+// request root depends on both the base URL and the version
+type graphOptions struct {
+	httpClient     *http.Client
+	baseURL        string
+	version        string
+	appSecret      string
+	debug          io.Writer
+	requestTimeout time.Duration
+}
+
+// WithHTTPClient sends requests through httpClient, e.g. one with a custom transport
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *graphOptions) { o.httpClient = httpClient }
+}
+
+// WithBaseURL points the client at another Graph API host, such as a local mock.
+// The version is appended to it.
+func WithBaseURL(baseURL string) Option {
+	return func(o *graphOptions) { o.baseURL = baseURL }
+}
+
+// WithVersion selects the Graph API version, e.g. v23.0; an empty version leaves
+// it out of request URLs
+func WithVersion(version string) Option {
+	return func(o *graphOptions) { o.version = version }
+}
+
+// WithAppSecret enables appsecret_proof on every request signed with the given app secret
+func WithAppSecret(appSecret string) Option {
+	return func(o *graphOptions) { o.appSecret = appSecret }
+}
+
+// WithDebugOutput writes a line per request to w, with the access token redacted
+func WithDebugOutput(w io.Writer) Option {
+	return func(o *graphOptions) { o.debug = w }
+}
+
+// WithRequestTimeout limits how long each request may take; 0 removes the limit
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(o *graphOptions) { o.requestTimeout = timeout }
 }
 
-// NewFacebookGraphClient creates a new Facebook Graph API client
-func NewFacebookGraphClient() *FacebookGraphClient {
+// NewFacebookGraphClient creates a new Facebook Graph API client. Without
+// options it calls DefaultBaseURL at DefaultVersion with DefaultRequestTimeout.
+func NewFacebookGraphClient(opts ...Option) *FacebookGraphClient {
+	o := graphOptions{
+		httpClient:     &http.Client{},
+		baseURL:        DefaultBaseURL,
+		version:        DefaultVersion,
+		requestTimeout: DefaultRequestTimeout,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	baseURL := strings.TrimRight(o.baseURL, "/")
+	if o.version != "" {
+		baseURL = baseURL + "/" + o.version
+	}
+
 	return &FacebookGraphClient{
-		httpClient: &http.Client{},
-		baseURL:    "https://graph.facebook.com/v23.0",
+		httpClient:     o.httpClient,
+		baseURL:        baseURL,
+		appSecret:      o.appSecret,
+		debug:          o.debug,
+		requestTimeout: o.requestTimeout,
 	}
 }
 
+// Endpoint returns the root that request URLs are built on, including the version
+func (c *FacebookGraphClient) Endpoint() string {
+	return c.baseURL
+}
+
+// SetRequestTimeout limits how long each request may take; 0 removes the limit
+func (c *FacebookGraphClient) SetRequestTimeout(timeout time.Duration) {
+	c.requestTimeout = timeout
+}
+
+// SetAppSecret enables appsecret_proof on every request signed with the given app secret
+func (c *FacebookGraphClient) SetAppSecret(appSecret string) {
+	c.appSecret = appSecret
+}
+
+// SetDebugOutput writes a line per request to w, with the access token redacted
+func (c *FacebookGraphClient) SetDebugOutput(w io.Writer) {
+	c.debug = w
+}
+
 // GetAnalytics fetches analytics data from Facebook Graph API
-func (c *FacebookGraphClient) GetAnalytics(wbaID string, start, end int64, granularity, accessToken string) (*models.AnalyticsResponse, error) {
+func (c *FacebookGraphClient) GetAnalytics(ctx context.Context, wbaID string, start, end int64, granularity, accessToken string) (*models.AnalyticsResponse, error) {
 	requestURL := fmt.Sprintf("%s/%s", c.baseURL, wbaID)
 	
 	params := url.Values{}
 	params.Add("fields", fmt.Sprintf("analytics.start(%d).end(%d).granularity(%s)", start, end, granularity))
-	params.Add("access_token", accessToken)
-	
-	fullURL := fmt.Sprintf("%s?%s", requestURL, params.Encode())
-	
-	resp, err := c.httpClient.Get(fullURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
-	}
-	defer resp.Body.Close()
-	
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-	
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
-	}
 	
 	var response models.AnalyticsResponse
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if err := c.get(ctx, requestURL, params, accessToken, &response); err != nil {
+		return nil, err
 	}
 	
 	return &response, nil
 }
 
 // GetTemplateAnalytics fetches template analytics data from Facebook Graph API
-func (c *FacebookGraphClient) GetTemplateAnalytics(wbaID string, start, end int64, granularity string, metricTypes []string, templateIDs []string, accessToken string) (*models.TemplateAnalyticsResponse, error) {
+func (c *FacebookGraphClient) GetTemplateAnalytics(ctx context.Context, wbaID string, start, end int64, granularity string, metricTypes []string, templateIDs []string, accessToken string) (*models.TemplateAnalyticsResponse, error) {
 	requestURL := fmt.Sprintf("%s/%s/template_analytics", c.baseURL, wbaID)
 	
 	params := url.Values{}
@@ -89,69 +170,153 @@ func (c *FacebookGraphClient) GetTemplateAnalytics(wbaID string, start, end int6
 		params.Add("template_ids", templateIDsJSON)
 	}
 	
-	params.Add("access_token", accessToken)
+	var response models.TemplateAnalyticsResponse
+	if err := c.get(ctx, requestURL, params, accessToken, &response); err != nil {
+		return nil, err
+	}
+	
+	return &response, nil
+}
+
+// ListTemplates fetches message templates from Facebook Graph API
+func (c *FacebookGraphClient) ListTemplates(ctx context.Context, wbaID string, accessToken string, limit int, after string) (*models.TemplateListResponse, error) {
+	requestURL := fmt.Sprintf("%s/%s/message_templates", c.baseURL, wbaID)
 	
-	fullURL := fmt.Sprintf("%s?%s", requestURL, params.Encode())
+	params := url.Values{}
 	
-	resp, err := c.httpClient.Get(fullURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+	if limit > 0 {
+		params.Add("limit", fmt.Sprintf("%d", limit))
 	}
-	defer resp.Body.Close()
 	
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+	if after != "" {
+		params.Add("after", after)
 	}
 	
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	var response models.TemplateListResponse
+	if err := c.get(ctx, requestURL, params, accessToken, &response); err != nil {
+		return nil, err
 	}
 	
-	var response models.TemplateAnalyticsResponse
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	return &response, nil
+}
+
+// GetBusinessAccount fetches account details such as the billing currency
+func (c *FacebookGraphClient) GetBusinessAccount(ctx context.Context, wbaID string, accessToken string) (*models.BusinessAccount, error) {
+	requestURL := fmt.Sprintf("%s/%s", c.baseURL, wbaID)
+	
+	params := url.Values{}
+	params.Add("fields", "id,name,currency,timezone_id,message_template_namespace")
+	
+	var response models.BusinessAccount
+	if err := c.get(ctx, requestURL, params, accessToken, &response); err != nil {
+		return nil, err
 	}
 	
 	return &response, nil
 }
 
-// ListTemplates fetches message templates from Facebook Graph API
-func (c *FacebookGraphClient) ListTemplates(wbaID string, accessToken string, limit int, after string) (*models.TemplateListResponse, error) {
-	requestURL := fmt.Sprintf("%s/%s/message_templates", c.baseURL, wbaID)
+// DebugToken inspects the access token itself using the debug_token endpoint
+func (c *FacebookGraphClient) DebugToken(ctx context.Context, accessToken string) (*models.TokenDebugResponse, error) {
+	requestURL := fmt.Sprintf("%s/debug_token", c.baseURL)
 	
+	// debug_token only accepts the inspected token as a query parameter;
+	// it is still redacted from errors and debug output
 	params := url.Values{}
-	params.Add("access_token", accessToken)
+	params.Add("input_token", accessToken)
 	
-	if limit > 0 {
-		params.Add("limit", fmt.Sprintf("%d", limit))
+	var response models.TokenDebugResponse
+	if err := c.get(ctx, requestURL, params, accessToken, &response); err != nil {
+		return nil, err
 	}
 	
-	if after != "" {
-		params.Add("after", after)
+	return &response, nil
+}
+
+// get performs an authenticated GET request and decodes the JSON body into out.
+// The access token travels in the Authorization header and never in the URL.
+// The request is abandoned when ctx is cancelled or the request timeout passes.
+func (c *FacebookGraphClient) get(ctx context.Context, requestURL string, params url.Values, accessToken string, out interface{}) error {
+	if c.appSecret != "" {
+		params.Set("appsecret_proof", AppSecretProof(accessToken, c.appSecret))
+	}
+	
+	fullURL := requestURL
+	if encoded := params.Encode(); encoded != "" {
+		fullURL = fmt.Sprintf("%s?%s", requestURL, encoded)
 	}
 	
-	fullURL := fmt.Sprintf("%s?%s", requestURL, params.Encode())
+	reqCtx := ctx
+	if c.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		reqCtx, cancel = context.WithTimeout(ctx, c.requestTimeout)
+		defer cancel()
+	}
 	
-	resp, err := c.httpClient.Get(fullURL)
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, fullURL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+		return fmt.Errorf("failed to create request: %s", redactToken(err.Error(), accessToken))
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	
+	if c.debug != nil {
+		fmt.Fprintf(c.debug, "GET %s\n", redactToken(fullURL, accessToken))
+	}
+	
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if ctxErr := c.contextError(ctx, reqCtx); ctxErr != nil {
+			return ctxErr
+		}
+		return fmt.Errorf("failed to make request: %s", redactToken(err.Error(), accessToken))
 	}
 	defer resp.Body.Close()
 	
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		if ctxErr := c.contextError(ctx, reqCtx); ctxErr != nil {
+			return ctxErr
+		}
+		return fmt.Errorf("failed to read response body: %s", redactToken(err.Error(), accessToken))
+	}
+	
+	if c.debug != nil {
+		fmt.Fprintf(c.debug, "<- %d (%d bytes)\n", resp.StatusCode, len(body))
 	}
 	
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, redactToken(string(body), accessToken))
 	}
 	
-	var response models.TemplateListResponse
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
 	}
 	
-	return &response, nil
-}
\ No newline at end of file
+	return nil
+}
+
+// contextError explains a request stopped by its context: the caller's
+// cancellation, or the per-request timeout. It returns nil for other failures.
+func (c *FacebookGraphClient) contextError(ctx, reqCtx context.Context) error {
+	if ctx.Err() != nil {
+		return fmt.Errorf("request cancelled: %w", context.Cause(ctx))
+	}
+	if reqCtx.Err() != nil {
+		return fmt.Errorf("request timed out after %s: %w", c.requestTimeout, reqCtx.Err())
+	}
+	return nil
+}
+
+// AppSecretProof computes the appsecret_proof value (HMAC-SHA256 of the token keyed by the app secret)
+func AppSecretProof(accessToken, appSecret string) string {
+	mac := hmac.New(sha256.New, []byte(appSecret))
+	mac.Write([]byte(accessToken))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// redactToken replaces every occurrence of the access token in s
+func redactToken(s, accessToken string) string {
+	if accessToken == "" {
+		return s
+	}
+	return strings.ReplaceAll(s, accessToken, "[REDACTED]")
+}
@@ -1,10 +1,14 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"wppanalyticscli/internal/models"
 )
@@ -45,7 +49,7 @@ func TestFacebookGraphClient_GetAnalytics(t *testing.T) {
 		baseURL:    server.URL,
 	}
 
-	response, err := client.GetAnalytics("932157148829117", 1750474800, 1750647600, "DAY", "test-token")
+	response, err := client.GetAnalytics(context.Background(), "932157148829117", 1750474800, 1750647600, "DAY", "test-token")
 	
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
@@ -81,7 +85,7 @@ func TestFacebookGraphClient_GetAnalyticsError(t *testing.T) {
 		baseURL:    server.URL,
 	}
 
-	_, err := client.GetAnalytics("932157148829117", 1750474800, 1750647600, "DAY", "invalid-token")
+	_, err := client.GetAnalytics(context.Background(), "932157148829117", 1750474800, 1750647600, "DAY", "invalid-token")
 	
 	if err == nil {
 		t.Errorf("Expected error for invalid token, but got none")
@@ -119,4 +123,284 @@ func TestMockResponseParsing(t *testing.T) {
 	if len(response.Analytics.PhoneNumbers) != 1 || response.Analytics.PhoneNumbers[0] != "551148619349" {
 		t.Errorf("Expected phone number '551148619349', got %v", response.Analytics.PhoneNumbers)
 	}
-}
\ No newline at end of file
+}
+
+func TestFacebookGraphClient_SendsTokenInHeader(t *testing.T) {
+	var gotAuth, gotQuery string
+	
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotQuery = r.URL.RawQuery
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data": []}`))
+	}))
+	defer server.Close()
+
+	client := &FacebookGraphClient{
+		httpClient: &http.Client{},
+		baseURL:    server.URL,
+	}
+
+	_, err := client.ListTemplates(context.Background(), "932157148829117", "secret-token", 10, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	
+	if gotAuth != "Bearer secret-token" {
+		t.Errorf("Expected 'Bearer secret-token' Authorization header, got '%s'", gotAuth)
+	}
+	
+	if strings.Contains(gotQuery, "access_token") || strings.Contains(gotQuery, "secret-token") {
+		t.Errorf("Access token leaked into query string: %s", gotQuery)
+	}
+	
+	if strings.Contains(gotQuery, "appsecret_proof") {
+		t.Errorf("Expected no appsecret_proof without app secret, got query: %s", gotQuery)
+	}
+}
+
+func TestFacebookGraphClient_AppSecretProof(t *testing.T) {
+	var gotProof string
+	
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotProof = r.URL.Query().Get("appsecret_proof")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data": []}`))
+	}))
+	defer server.Close()
+
+	client := &FacebookGraphClient{
+		httpClient: &http.Client{},
+		baseURL:    server.URL,
+	}
+	client.SetAppSecret("app-secret")
+
+	_, err := client.ListTemplates(context.Background(), "932157148829117", "secret-token", 10, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	
+	// HMAC-SHA256("secret-token") keyed with "app-secret"
+	expected := AppSecretProof("secret-token", "app-secret")
+	if gotProof != expected {
+		t.Errorf("Expected appsecret_proof '%s', got '%s'", expected, gotProof)
+	}
+	
+	if len(gotProof) != 64 {
+		t.Errorf("Expected a 64 character hex digest, got %d characters", len(gotProof))
+	}
+}
+
+func TestFacebookGraphClient_RedactsTokenInErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": {"message": "Malformed access token secret-token"}}`))
+	}))
+	defer server.Close()
+
+	var debugOutput strings.Builder
+	client := &FacebookGraphClient{
+		httpClient: &http.Client{},
+		baseURL:    server.URL,
+	}
+	client.SetDebugOutput(&debugOutput)
+
+	_, err := client.GetAnalytics(context.Background(), "932157148829117", 1750474800, 1750647600, "DAY", "secret-token")
+	if err == nil {
+		t.Fatal("Expected error, but got none")
+	}
+	
+	if strings.Contains(err.Error(), "secret-token") {
+		t.Errorf("Access token leaked into error: %v", err)
+	}
+	
+	if !strings.Contains(err.Error(), "[REDACTED]") {
+		t.Errorf("Expected redaction marker in error: %v", err)
+	}
+	
+	if strings.Contains(debugOutput.String(), "secret-token") {
+		t.Errorf("Access token leaked into debug output: %s", debugOutput.String())
+	}
+}
+
+func TestFacebookGraphClient_DebugToken(t *testing.T) {
+	mockResponse := `{
+		"data": {
+			"app_id": "138483919580948",
+			"type": "SYSTEM_USER",
+			"application": "Analytics Bot",
+			"data_access_expires_at": 0,
+			"expires_at": 0,
+			"is_valid": true,
+			"scopes": ["whatsapp_business_management", "business_management"],
+			"granular_scopes": [{
+				"scope": "whatsapp_business_management",
+				"target_ids": ["932157148829117"]
+			}],
+			"user_id": "122105405228166"
+		}
+	}`
+
+	var gotPath, gotInputToken string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotInputToken = r.URL.Query().Get("input_token")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(mockResponse))
+	}))
+	defer server.Close()
+
+	client := &FacebookGraphClient{
+		httpClient: &http.Client{},
+		baseURL:    server.URL,
+	}
+
+	response, err := client.DebugToken(context.Background(), "test-token")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	
+	if gotPath != "/debug_token" || gotInputToken != "test-token" {
+		t.Errorf("Unexpected request path '%s' with input_token '%s'", gotPath, gotInputToken)
+	}
+	
+	if response.Data.Type != "SYSTEM_USER" || !response.Data.IsValid {
+		t.Errorf("Unexpected token data: %+v", response.Data)
+	}
+	
+	if len(response.Data.GranularScopes) != 1 || response.Data.GranularScopes[0].TargetIDs[0] != "932157148829117" {
+		t.Errorf("Expected granular scope for WABA, got %+v", response.Data.GranularScopes)
+	}
+}
+
+func TestFacebookGraphClient_GetBusinessAccount(t *testing.T) {
+	mockResponse := `{
+		"id": "932157148829117",
+		"name": "Acme Brasil",
+		"currency": "BRL",
+		"timezone_id": "25",
+		"message_template_namespace": "a1b2c3"
+	}`
+
+	var gotPath, gotFields string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotFields = r.URL.Query().Get("fields")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(mockResponse))
+	}))
+	defer server.Close()
+
+	client := &FacebookGraphClient{
+		httpClient: &http.Client{},
+		baseURL:    server.URL,
+	}
+
+	account, err := client.GetBusinessAccount(context.Background(), "932157148829117", "test-token")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if gotPath != "/932157148829117" || !strings.Contains(gotFields, "currency") {
+		t.Errorf("Unexpected request path '%s' with fields '%s'", gotPath, gotFields)
+	}
+
+	if account.Currency != "BRL" || account.Name != "Acme Brasil" {
+		t.Errorf("Unexpected account: %+v", account)
+	}
+}
+
+func TestFacebookGraphClient_RequestTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := &FacebookGraphClient{
+		httpClient: &http.Client{},
+		baseURL:    server.URL,
+	}
+	client.SetRequestTimeout(50 * time.Millisecond)
+
+	_, err := client.GetAnalytics(context.Background(), "932157148829117", 1750474800, 1750647600, "DAY", "test-token")
+	if err == nil {
+		t.Fatal("Expected timeout error, but got none")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "timed out after 50ms") {
+		t.Errorf("Expected request timeout error, got %v", err)
+	}
+}
+
+func TestFacebookGraphClient_Cancelled(t *testing.T) {
+	started := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := &FacebookGraphClient{
+		httpClient: &http.Client{},
+		baseURL:    server.URL,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	_, err := client.ListTemplates(ctx, "932157148829117", "test-token", 10, "")
+	if !errors.Is(err, context.Canceled) || !strings.Contains(err.Error(), "request cancelled") {
+		t.Errorf("Expected cancellation error, got %v", err)
+	}
+}
+
+func TestNewFacebookGraphClient_Options(t *testing.T) {
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Write([]byte(`{"id": "932157148829117", "currency": "BRL"}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		expected string
+	}{
+		{"Custom version", []Option{WithVersion("v21.0")}, "/v21.0/932157148829117"},
+		{"Trailing slash", []Option{WithBaseURL(server.URL + "/"), WithVersion("v21.0")}, "/v21.0/932157148829117"},
+		{"No version", []Option{WithVersion("")}, "/932157148829117"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]Option{WithHTTPClient(server.Client()), WithBaseURL(server.URL)}, tt.opts...)
+			client := NewFacebookGraphClient(opts...)
+
+			if _, err := client.GetBusinessAccount(context.Background(), "932157148829117", "test-token"); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if gotPath != tt.expected {
+				t.Errorf("Expected path '%s', got '%s'", tt.expected, gotPath)
+			}
+		})
+	}
+}
+
+func TestNewFacebookGraphClient_Defaults(t *testing.T) {
+	client := NewFacebookGraphClient()
+
+	if client.Endpoint() != "https://graph.facebook.com/v23.0" {
+		t.Errorf("Expected default endpoint, got '%s'", client.Endpoint())
+	}
+	if client.requestTimeout != DefaultRequestTimeout {
+		t.Errorf("Expected request timeout %s, got %s", DefaultRequestTimeout, client.requestTimeout)
+	}
+}
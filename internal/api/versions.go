@@ -0,0 +1,90 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the Graph API host requests go to unless configured otherwise
+const DefaultBaseURL = "https://graph.facebook.com"
+
+// DefaultVersion is the Graph API version requests use unless configured otherwise
+const DefaultVersion = "v23.0"
+
+// SunsetWarningWindow is how long before a version's sunset date SunsetWarning starts warning
+const SunsetWarningWindow = 90 * 24 * time.Hour
+
+// versionPattern matches Graph API versions such as v23.0
+var versionPattern = regexp.MustCompile(`^v(\d+)\.(\d+)$`)
+
+// versionSunsets are the dates after which Meta stops serving each version, from
+// the Graph API changelog. Versions without an announced date are not listed.
+var versionSunsets = map[string]string{
+	"v16.0": "2025-05-14",
+	"v17.0": "2025-09-12",
+	"v18.0": "2026-01-26",
+	"v19.0": "2026-05-21",
+	"v20.0": "2026-09-24",
+}
+
+// oldestListedMajor is the oldest version in versionSunsets; anything older is long retired
+const oldestListedMajor = 16
+
+// IsValidVersion reports whether version looks like a Graph API version, e.g. v23.0
+func IsValidVersion(version string) bool {
+	return versionPattern.MatchString(version)
+}
+
+// ValidateEndpoint checks a Graph API base URL, which must not carry the version,
+// and a version such as v23.0
+func ValidateEndpoint(baseURL, version string) error {
+	parsed, err := url.Parse(baseURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("invalid API base URL '%s': expected http(s)://host[/path]", baseURL)
+	}
+
+	segments := strings.Split(strings.TrimRight(parsed.Path, "/"), "/")
+	if IsValidVersion(segments[len(segments)-1]) {
+		return fmt.Errorf("API base URL '%s' should not include the version; use -api-version", baseURL)
+	}
+
+	if !IsValidVersion(version) {
+		return fmt.Errorf("invalid API version '%s': expected a Graph API version such as %s", version, DefaultVersion)
+	}
+	return nil
+}
+
+// SunsetWarning explains when version has been or is about to be retired by Meta,
+// and returns an empty string for versions with no sunset within SunsetWarningWindow
+func SunsetWarning(version string, now time.Time) string {
+	match := versionPattern.FindStringSubmatch(version)
+	if match == nil {
+		return ""
+	}
+
+	sunset, ok := versionSunsets[version]
+	if !ok {
+		if major, _ := strconv.Atoi(match[1]); major < oldestListedMajor {
+			return fmt.Sprintf("Graph API %s has been retired, requests will fail; use %s", version, DefaultVersion)
+		}
+		return ""
+	}
+
+	date, err := time.Parse("2006-01-02", sunset)
+	if err != nil {
+		return ""
+	}
+	if !now.Before(date) {
+		return fmt.Sprintf("Graph API %s was retired on %s, requests will fail; use %s", version, sunset, DefaultVersion)
+	}
+	if date.Sub(now) <= SunsetWarningWindow {
+		days := int(math.Ceil(date.Sub(now).Hours() / 24))
+		return fmt.Sprintf("Graph API %s will be retired on %s (in %d days); move to %s", version, sunset, days, DefaultVersion)
+	}
+	return ""
+}
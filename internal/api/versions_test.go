@@ -0,0 +1,88 @@
+package api
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIsValidVersion(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected bool
+	}{
+		{"v23.0", true},
+		{"v9.1", true},
+		{"23.0", false},
+		{"v23", false},
+		{"latest", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsValidVersion(tt.version); got != tt.expected {
+			t.Errorf("IsValidVersion(%q): expected %v, got %v", tt.version, tt.expected, got)
+		}
+	}
+}
+
+func TestValidateEndpoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		baseURL  string
+		version  string
+		hasError bool
+	}{
+		{"Default endpoint", DefaultBaseURL, DefaultVersion, false},
+		{"Local mock", "http://localhost:8080", "v21.0", false},
+		{"Mock under a path", "http://localhost:8080/graph/", "v21.0", false},
+		{"Base URL with version", "https://graph.facebook.com/v23.0", DefaultVersion, true},
+		{"Missing scheme", "graph.facebook.com", DefaultVersion, true},
+		{"Invalid version", DefaultBaseURL, "23", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEndpoint(tt.baseURL, tt.version)
+			if tt.hasError && err == nil {
+				t.Errorf("Expected error but got none")
+			}
+			if !tt.hasError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestSunsetWarning(t *testing.T) {
+	now := time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		version  string
+		contains string // Empty when no warning is expected
+	}{
+		{"Current default", DefaultVersion, ""},
+		{"Unlisted version", "v21.0", ""},
+		{"Sunset far away", "v20.0", ""},
+		{"Sunset within window", "v19.0", "will be retired on 2026-05-21 (in 50 days)"},
+		{"Already retired", "v18.0", "was retired on 2026-01-26"},
+		{"Older than listed", "v12.0", "has been retired"},
+		{"Invalid version", "latest", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warning := SunsetWarning(tt.version, now)
+			if tt.contains == "" {
+				if warning != "" {
+					t.Errorf("Expected no warning, got '%s'", warning)
+				}
+				return
+			}
+			if !strings.Contains(warning, tt.contains) {
+				t.Errorf("Expected warning containing '%s', got '%s'", tt.contains, warning)
+			}
+		})
+	}
+}
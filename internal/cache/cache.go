@@ -0,0 +1,200 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Store is an on-disk cache of API responses, one JSON file per key
+type Store struct {
+	dir string
+	now func() time.Time
+}
+
+// entry is the file format of a cached response
+type entry struct {
+	Key       string          `json:"key"`
+	StoredAt  int64           `json:"stored_at"`
+	ExpiresAt int64           `json:"expires_at,omitempty"` // 0 never expires
+	Body      json.RawMessage `json:"body"`
+}
+
+// Forever is the TTL of entries kept until the cache is cleared
+const Forever time.Duration = -1
+
+// Stats summarizes the cache contents
+type Stats struct {
+	Dir     string
+	Entries int
+	Expired int
+	Bytes   int64
+	Oldest  time.Time
+	Newest  time.Time
+}
+
+// DefaultDir returns the cache directory under the user cache dir
+func DefaultDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("could not locate the user cache directory: %w", err)
+	}
+	return filepath.Join(base, "wppanalyticscli"), nil
+}
+
+// NewStore creates a cache stored in dir, which is created on first write
+func NewStore(dir string) *Store {
+	return &Store{dir: dir, now: time.Now}
+}
+
+// Dir returns the cache directory
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Get returns the body cached under key, if present and not expired
+func (s *Store) Get(key string) ([]byte, bool) {
+	e, err := s.read(s.path(key))
+	if err != nil || e.Key != key || s.expired(e) {
+		return nil, false
+	}
+	return e.Body, true
+}
+
+// Put caches body under key for ttl, or until cleared with Forever. A ttl of 0
+// does not cache.
+func (s *Store) Put(key string, body []byte, ttl time.Duration) error {
+	if ttl == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	now := s.now()
+	e := entry{Key: key, StoredAt: now.Unix(), Body: body}
+	if ttl > 0 {
+		e.ExpiresAt = now.Add(ttl).Unix()
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+
+	// Write to a temporary file and rename, so readers never see a partial entry
+	tmp, err := os.CreateTemp(s.dir, "entry-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path(key)); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	return nil
+}
+
+// Clear removes every cached entry and returns how many were removed
+func (s *Store) Clear() (int, error) {
+	files, err := s.files()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, file := range files {
+		if err := os.Remove(file); err != nil {
+			return removed, fmt.Errorf("failed to remove cache entry: %w", err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// Stats counts the cached entries, their size and how many have expired
+func (s *Store) Stats() (Stats, error) {
+	stats := Stats{Dir: s.dir}
+
+	files, err := s.files()
+	if err != nil {
+		return stats, err
+	}
+
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		stats.Entries++
+		stats.Bytes += info.Size()
+
+		e, err := s.read(file)
+		if err != nil || s.expired(e) {
+			stats.Expired++
+			continue
+		}
+		stored := time.Unix(e.StoredAt, 0)
+		if stats.Oldest.IsZero() || stored.Before(stats.Oldest) {
+			stats.Oldest = stored
+		}
+		if stored.After(stats.Newest) {
+			stats.Newest = stored
+		}
+	}
+	return stats, nil
+}
+
+// path returns the file for key; keys are hashed so parameters never appear in file names
+func (s *Store) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// files lists the cache entry files, or none when the directory does not exist
+func (s *Store) files() ([]string, error) {
+	dirEntries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	var files []string
+	for _, dirEntry := range dirEntries {
+		if !dirEntry.IsDir() && strings.HasSuffix(dirEntry.Name(), ".json") {
+			files = append(files, filepath.Join(s.dir, dirEntry.Name()))
+		}
+	}
+	return files, nil
+}
+
+// read decodes a cache entry file
+func (s *Store) read(file string) (entry, error) {
+	var e entry
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return e, err
+	}
+	err = json.Unmarshal(data, &e)
+	return e, err
+}
+
+// expired reports whether an entry is past its expiry
+func (s *Store) expired(e entry) bool {
+	return e.ExpiresAt != 0 && s.now().Unix() >= e.ExpiresAt
+}
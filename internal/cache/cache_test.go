@@ -0,0 +1,94 @@
+package cache
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestStore_PutGet(t *testing.T) {
+	store := NewStore(t.TempDir())
+	now := time.Unix(1750388400, 0)
+	store.now = func() time.Time { return now }
+
+	if err := store.Put("analytics|1", []byte(`{"id":"1"}`), time.Minute); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	body, ok := store.Get("analytics|1")
+	if !ok || string(body) != `{"id":"1"}` {
+		t.Errorf("Expected cached body, got %q (hit %v)", body, ok)
+	}
+
+	if _, ok := store.Get("analytics|2"); ok {
+		t.Errorf("Expected miss for unknown key")
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok := store.Get("analytics|1"); ok {
+		t.Errorf("Expected entry to expire after its TTL")
+	}
+}
+
+func TestStore_Forever(t *testing.T) {
+	store := NewStore(t.TempDir())
+	now := time.Unix(1750388400, 0)
+	store.now = func() time.Time { return now }
+
+	if err := store.Put("closed", []byte(`{}`), Forever); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := store.Put("disabled", []byte(`{}`), 0); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	now = now.AddDate(1, 0, 0)
+	if _, ok := store.Get("closed"); !ok {
+		t.Errorf("Expected entry without TTL to be kept")
+	}
+	if _, ok := store.Get("disabled"); ok {
+		t.Errorf("Expected entry with zero TTL not to be cached")
+	}
+}
+
+func TestStore_StatsAndClear(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir)
+	now := time.Unix(1750388400, 0)
+	store.now = func() time.Time { return now }
+
+	store.Put("a", []byte(`{}`), Forever)
+	store.Put("b", []byte(`{}`), time.Minute)
+	now = now.Add(time.Hour)
+
+	stats, err := store.Stats()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if stats.Entries != 2 || stats.Expired != 1 || stats.Bytes == 0 {
+		t.Errorf("Expected 2 entries with 1 expired, got %+v", stats)
+	}
+
+	// Temporary files from interrupted writes are not entries
+	os.WriteFile(dir+"/entry-1.tmp", []byte("partial"), 0600)
+
+	removed, err := store.Clear()
+	if err != nil || removed != 2 {
+		t.Errorf("Expected 2 entries removed, got %d (%v)", removed, err)
+	}
+	if stats, _ := store.Stats(); stats.Entries != 0 {
+		t.Errorf("Expected empty cache after clear, got %d entries", stats.Entries)
+	}
+}
+
+func TestStore_MissingDir(t *testing.T) {
+	store := NewStore(t.TempDir() + "/missing")
+
+	stats, err := store.Stats()
+	if err != nil || stats.Entries != 0 {
+		t.Errorf("Expected empty stats for a missing directory, got %+v (%v)", stats, err)
+	}
+	if removed, err := store.Clear(); err != nil || removed != 0 {
+		t.Errorf("Expected nothing to clear, got %d (%v)", removed, err)
+	}
+}
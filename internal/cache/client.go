@@ -0,0 +1,183 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"wppanalyticscli/internal/api"
+	"wppanalyticscli/internal/models"
+)
+
+// Client wraps an api.Client and serves repeated requests from a Store. Keys are
+// built from the endpoint and normalized parameters and never include the token.
+type Client struct {
+	next    api.Client
+	store   *Store
+	ttl     time.Duration
+	refresh bool
+	scope   string
+	loc     *time.Location
+	debug   io.Writer
+	now     func() time.Time
+}
+
+// SettleDays is how many days before today a window must end to be cached until
+// cleared, since Meta keeps backfilling late delivered and read counts
+const SettleDays = 3
+
+// NewClient caches the responses of next in store. Windows that ended at least
+// SettleDays before today are kept until cleared; other responses expire after
+// ttl, and are not cached when it is 0.
+func NewClient(next api.Client, store *Store, ttl time.Duration) *Client {
+	return &Client{next: next, store: store, ttl: ttl, loc: time.Local, now: time.Now}
+}
+
+// SetRefresh skips cached responses and replaces them with fresh ones
+func (c *Client) SetRefresh(refresh bool) {
+	c.refresh = refresh
+}
+
+// SetLocation sets the timezone whose day boundaries decide when a window is closed
+func (c *Client) SetLocation(loc *time.Location) {
+	c.loc = loc
+}
+
+// SetScope keeps the responses of a non-default API endpoint, such as a local
+// mock or another Graph version, apart from those of the default endpoint
+func (c *Client) SetScope(scope string) {
+	c.scope = scope
+}
+
+// SetDebugOutput writes a line per cache hit to w
+func (c *Client) SetDebugOutput(w io.Writer) {
+	c.debug = w
+}
+
+// GetAnalytics returns cached analytics for the window, fetching them on a miss
+func (c *Client) GetAnalytics(ctx context.Context, wbaID string, start, end int64, granularity, accessToken string) (*models.AnalyticsResponse, error) {
+	key := fmt.Sprintf("analytics|%s|%d|%d|%s", wbaID, start, end, strings.ToUpper(granularity))
+
+	var response models.AnalyticsResponse
+	err := c.cached(key, c.windowTTL(end), &response, func() (interface{}, error) {
+		return c.next.GetAnalytics(ctx, wbaID, start, end, granularity, accessToken)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetTemplateAnalytics returns cached template analytics for the window, fetching them on a miss
+func (c *Client) GetTemplateAnalytics(ctx context.Context, wbaID string, start, end int64, granularity string, metricTypes []string, templateIDs []string, accessToken string) (*models.TemplateAnalyticsResponse, error) {
+	key := fmt.Sprintf("template_analytics|%s|%d|%d|%s|%s|%s", wbaID, start, end, strings.ToUpper(granularity),
+		normalizeList(metricTypes, true), normalizeList(templateIDs, false))
+
+	var response models.TemplateAnalyticsResponse
+	err := c.cached(key, c.windowTTL(end), &response, func() (interface{}, error) {
+		return c.next.GetTemplateAnalytics(ctx, wbaID, start, end, granularity, metricTypes, templateIDs, accessToken)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// ListTemplates returns a cached page of templates, fetching it on a miss
+func (c *Client) ListTemplates(ctx context.Context, wbaID string, accessToken string, limit int, after string) (*models.TemplateListResponse, error) {
+	key := fmt.Sprintf("message_templates|%s|%d|%s", wbaID, limit, after)
+
+	var response models.TemplateListResponse
+	err := c.cached(key, c.ttl, &response, func() (interface{}, error) {
+		return c.next.ListTemplates(ctx, wbaID, accessToken, limit, after)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetBusinessAccount returns the cached account, fetching it on a miss
+func (c *Client) GetBusinessAccount(ctx context.Context, wbaID string, accessToken string) (*models.BusinessAccount, error) {
+	key := fmt.Sprintf("account|%s", wbaID)
+
+	var response models.BusinessAccount
+	err := c.cached(key, c.ttl, &response, func() (interface{}, error) {
+		return c.next.GetBusinessAccount(ctx, wbaID, accessToken)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// DebugToken is never cached, so token checks always reflect the current token
+func (c *Client) DebugToken(ctx context.Context, accessToken string) (*models.TokenDebugResponse, error) {
+	return c.next.DebugToken(ctx, accessToken)
+}
+
+// cached decodes the entry for key into out, or calls fetch and caches its result for ttl
+func (c *Client) cached(key string, ttl time.Duration, out interface{}, fetch func() (interface{}, error)) error {
+	if c.scope != "" {
+		key = c.scope + "|" + key
+	}
+
+	if !c.refresh {
+		if body, ok := c.store.Get(key); ok && json.Unmarshal(body, out) == nil {
+			if c.debug != nil {
+				fmt.Fprintf(c.debug, "CACHE HIT %s\n", key)
+			}
+			return nil
+		}
+	}
+
+	response, err := fetch()
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("failed to encode response: %w", err)
+	}
+	if err := c.store.Put(key, body, ttl); err != nil && c.debug != nil {
+		fmt.Fprintf(c.debug, "CACHE WRITE FAILED %s: %v\n", key, err)
+	}
+	return json.Unmarshal(body, out)
+}
+
+// windowTTL keeps closed windows, which end SettleDays or more before the start
+// of today, until cleared and expires every other window after the configured
+// TTL: unfinished days and recent days can still change
+func (c *Client) windowTTL(end int64) time.Duration {
+	now := c.now().In(c.loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, c.loc)
+	if end <= today.AddDate(0, 0, -SettleDays).Unix() {
+		return Forever
+	}
+	return c.ttl
+}
+
+// normalizeList trims, de-duplicates and sorts list parameters so equivalent
+// requests share a key
+func normalizeList(values []string, fold bool) string {
+	seen := make(map[string]bool)
+	var normalized []string
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if fold {
+			value = strings.ToLower(value)
+		}
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		normalized = append(normalized, value)
+	}
+	sort.Strings(normalized)
+	return strings.Join(normalized, ",")
+}
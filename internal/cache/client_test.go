@@ -0,0 +1,209 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"wppanalyticscli/internal/models"
+)
+
+// fakeClient counts the requests that reach the API
+type fakeClient struct {
+	calls int
+	err   error
+}
+
+func (f *fakeClient) GetAnalytics(ctx context.Context, wbaID string, start, end int64, granularity, accessToken string) (*models.AnalyticsResponse, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	response := &models.AnalyticsResponse{ID: wbaID}
+	response.Analytics.Granularity = granularity
+	response.Analytics.DataPoints = []models.DataPoint{{Start: start, End: end, Sent: f.calls}}
+	return response, nil
+}
+
+func (f *fakeClient) GetTemplateAnalytics(ctx context.Context, wbaID string, start, end int64, granularity string, metricTypes []string, templateIDs []string, accessToken string) (*models.TemplateAnalyticsResponse, error) {
+	f.calls++
+	return &models.TemplateAnalyticsResponse{Data: []models.TemplateAnalyticsData{{Granularity: granularity}}}, nil
+}
+
+func (f *fakeClient) ListTemplates(ctx context.Context, wbaID string, accessToken string, limit int, after string) (*models.TemplateListResponse, error) {
+	f.calls++
+	return &models.TemplateListResponse{Data: []models.MessageTemplate{{ID: "1", Name: "welcome"}}}, nil
+}
+
+func (f *fakeClient) DebugToken(ctx context.Context, accessToken string) (*models.TokenDebugResponse, error) {
+	f.calls++
+	return &models.TokenDebugResponse{}, nil
+}
+
+func (f *fakeClient) GetBusinessAccount(ctx context.Context, wbaID string, accessToken string) (*models.BusinessAccount, error) {
+	f.calls++
+	return &models.BusinessAccount{ID: wbaID, Currency: "BRL"}, nil
+}
+
+func newTestClient(t *testing.T, ttl time.Duration) (*Client, *fakeClient, *time.Time) {
+	t.Helper()
+	now := time.Unix(1750820400, 0)
+	fake := &fakeClient{}
+	store := NewStore(t.TempDir())
+	store.now = func() time.Time { return now }
+	client := NewClient(fake, store, ttl)
+	client.SetLocation(time.UTC)
+	client.now = func() time.Time { return now }
+	return client, fake, &now
+}
+
+func TestClient_ClosedWindowCachedIndefinitely(t *testing.T) {
+	client, fake, now := newTestClient(t, time.Minute)
+
+	first, err := client.GetAnalytics(context.Background(), "123", 1750388400, 1750474800, "DAY", "token-a")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	*now = now.AddDate(0, 1, 0)
+	second, err := client.GetAnalytics(context.Background(), "123", 1750388400, 1750474800, "day", "token-b")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if fake.calls != 1 {
+		t.Errorf("Expected 1 API call, got %d", fake.calls)
+	}
+	if second.Analytics.DataPoints[0].Sent != first.Analytics.DataPoints[0].Sent {
+		t.Errorf("Expected cached response, got %+v", second)
+	}
+}
+
+func TestClient_OpenWindowExpires(t *testing.T) {
+	client, fake, now := newTestClient(t, time.Minute)
+	end := now.Add(time.Hour).Unix()
+
+	client.GetAnalytics(context.Background(), "123", 1750788400, end, "DAY", "token")
+	client.GetAnalytics(context.Background(), "123", 1750788400, end, "DAY", "token")
+	if fake.calls != 1 {
+		t.Errorf("Expected 1 API call within the TTL, got %d", fake.calls)
+	}
+
+	*now = now.Add(2 * time.Minute)
+	client.GetAnalytics(context.Background(), "123", 1750788400, end, "DAY", "token")
+	if fake.calls != 2 {
+		t.Errorf("Expected a new API call after the TTL, got %d calls", fake.calls)
+	}
+}
+
+func TestClient_EndedEarlierTodayExpires(t *testing.T) {
+	client, fake, now := newTestClient(t, time.Minute)
+	*now = now.Add(12 * time.Hour)
+	end := now.Add(-3 * time.Hour).Unix()
+
+	client.GetAnalytics(context.Background(), "123", 1750788400, end, "DAY", "token")
+	*now = now.Add(2 * time.Minute)
+	client.GetAnalytics(context.Background(), "123", 1750788400, end, "DAY", "token")
+	if fake.calls != 2 {
+		t.Errorf("Expected a window ending earlier today to expire after the TTL, got %d calls", fake.calls)
+	}
+}
+
+func TestClient_RecentDaysExpire(t *testing.T) {
+	client, fake, now := newTestClient(t, time.Minute)
+	end := now.AddDate(0, 0, -1).Unix()
+
+	client.GetAnalytics(context.Background(), "123", end-86400, end, "DAY", "token")
+	*now = now.Add(2 * time.Minute)
+	client.GetAnalytics(context.Background(), "123", end-86400, end, "DAY", "token")
+	if fake.calls != 2 {
+		t.Errorf("Expected a window within the settle period to expire after the TTL, got %d calls", fake.calls)
+	}
+}
+
+func TestClient_Refresh(t *testing.T) {
+	client, fake, _ := newTestClient(t, time.Minute)
+
+	client.GetBusinessAccount(context.Background(), "123", "token")
+	client.SetRefresh(true)
+	client.GetBusinessAccount(context.Background(), "123", "token")
+	client.SetRefresh(false)
+	account, _ := client.GetBusinessAccount(context.Background(), "123", "token")
+
+	if fake.calls != 2 {
+		t.Errorf("Expected refresh to bypass the cache once, got %d calls", fake.calls)
+	}
+	if account.Currency != "BRL" {
+		t.Errorf("Expected cached account, got %+v", account)
+	}
+}
+
+func TestClient_ScopeSeparatesEndpoints(t *testing.T) {
+	client, fake, _ := newTestClient(t, time.Minute)
+
+	client.GetBusinessAccount(context.Background(), "123", "token")
+	client.SetScope("http://localhost:8080/v23.0")
+	client.GetBusinessAccount(context.Background(), "123", "token")
+	client.GetBusinessAccount(context.Background(), "123", "token")
+	if fake.calls != 2 {
+		t.Errorf("Expected one API call per scope, got %d", fake.calls)
+	}
+}
+
+func TestClient_NormalizedTemplateKey(t *testing.T) {
+	client, fake, _ := newTestClient(t, time.Minute)
+
+	client.GetTemplateAnalytics(context.Background(), "123", 1750388400, 1750474800, "DAILY", []string{"sent", "cost"}, []string{"2", "1"}, "token")
+	client.GetTemplateAnalytics(context.Background(), "123", 1750388400, 1750474800, "DAILY", []string{"COST", " sent", "sent"}, []string{"1", "2"}, "token")
+	client.GetTemplateAnalytics(context.Background(), "123", 1750388400, 1750474800, "DAILY", []string{"sent"}, []string{"1", "2"}, "token")
+
+	if fake.calls != 2 {
+		t.Errorf("Expected equivalent parameters to share an entry, got %d calls", fake.calls)
+	}
+}
+
+func TestClient_TokenNeverStored(t *testing.T) {
+	client, _, _ := newTestClient(t, time.Minute)
+
+	client.GetAnalytics(context.Background(), "123", 1750388400, 1750474800, "DAY", "secret-token-value")
+	client.ListTemplates(context.Background(), "123", "secret-token-value", 25, "")
+
+	files, _ := os.ReadDir(client.store.Dir())
+	if len(files) != 2 {
+		t.Fatalf("Expected 2 cache files, got %d", len(files))
+	}
+	for _, file := range files {
+		data, _ := os.ReadFile(client.store.Dir() + "/" + file.Name())
+		if strings.Contains(string(data), "secret-token-value") || strings.Contains(file.Name(), "secret") {
+			t.Errorf("Cache entry %s contains the access token", file.Name())
+		}
+	}
+}
+
+func TestClient_ErrorsNotCached(t *testing.T) {
+	client, fake, _ := newTestClient(t, time.Minute)
+	fake.err = errors.New("API request failed with status 500")
+
+	if _, err := client.GetAnalytics(context.Background(), "123", 1750388400, 1750474800, "DAY", "token"); err == nil {
+		t.Fatalf("Expected error")
+	}
+	fake.err = nil
+	if _, err := client.GetAnalytics(context.Background(), "123", 1750388400, 1750474800, "DAY", "token"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fake.calls != 2 {
+		t.Errorf("Expected failed request to be retried, got %d calls", fake.calls)
+	}
+}
+
+func TestClient_DebugTokenNotCached(t *testing.T) {
+	client, fake, _ := newTestClient(t, time.Minute)
+
+	client.DebugToken(context.Background(), "token")
+	client.DebugToken(context.Background(), "token")
+	if fake.calls != 2 {
+		t.Errorf("Expected debug_token to bypass the cache, got %d calls", fake.calls)
+	}
+}
@@ -3,16 +3,54 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
+	"time"
+
+	"wppanalyticscli/internal/datetime"
 )
 
 // Config holds the application configuration
 type Config struct {
-	WBAID       string
-	StartDate   string
-	EndDate     string
-	Granularity string
-	Timezone    string
-	AccessToken string
+	WBAID         string
+	Accounts      []string // Every WABA of a multi-account report, WBAID is the first
+	Concurrency   int      // Accounts fetched at once in a multi-account report
+	StartDate     string
+	EndDate       string
+	Range         string // Named range preset, replaces StartDate/EndDate
+	Granularity   string
+	Timezone      string
+	InputTimezone string // Timezone for date-only and naive inputs, defaults to Timezone
+	AccessToken   string
+	AppSecret     string // Optional, enables appsecret_proof
+	Align         bool   // Snap the date window to granularity boundaries
+	WeekStart     string // "iso" (Monday) or a weekday name, for WEEK rollups
+	Compare       string // previous-period, previous-year or <start>..<end>
+	View          string // "table" or "funnel" (template mode)
+	Output        string // "text" (default), "markdown", "html" or "parquet"
+	OutFile       string // Destination file for parquet output
+	Chart         bool   // Draw terminal charts under the text tables
+	TableStyle    string // "box" (default) or "ascii" table borders
+	Color         string // "auto" (default), "always" or "never"
+	Plain         bool   // Emoji-free, uncolored ASCII text for logs
+	CustomFormat  string // -format-template: Go text/template file or inline text
+	GroupBy       string // "template" or "date" (template mode)
+	Sort          string // Table sort key per mode, see isValidSort
+	Currency      string // ISO 4217 code for costs, fetched from the WABA when empty
+	Numbers       string // "exact" or "short" (default) count formatting
+	Locale        string // Locale for thousands and decimal separators, e.g. pt-BR
+	// Response cache
+	NoCache       bool          // Always call the API, without reading or writing the cache
+	Refresh       bool          // Call the API and replace cached responses
+	CacheTTL      time.Duration // Lifetime of responses for windows that include the present
+	// Cancellation
+	Timeout       time.Duration // Limit for the whole run, 0 for none
+	ReqTimeout    time.Duration // Limit for each API request, 0 for none
+	// Graph API endpoint
+	APIBaseURL    string // Graph API host, e.g. a local mock; see LoadAPIEndpoint
+	APIVersion    string // Graph API version such as v23.0; see LoadAPIEndpoint
+	// Local history
+	Source        string // "api" (default) or "local" to read reports from the synced history
+	HistoryPath   string // History database, defaults to history.DefaultPath
 	// Template analytics specific fields
 	Mode         string   // "analytics", "template", or "list-templates"
 	MetricTypes  []string // For template analytics
@@ -28,11 +66,15 @@ type Validator interface {
 }
 
 // ConfigValidator implements the Validator interface
-type ConfigValidator struct{}
+type ConfigValidator struct {
+	now func() time.Time
+}
 
 // NewConfigValidator creates a new configuration validator
 func NewConfigValidator() *ConfigValidator {
-	return &ConfigValidator{}
+	return &ConfigValidator{
+		now: time.Now,
+	}
 }
 
 // Validate validates the configuration
@@ -42,7 +84,15 @@ func (v *ConfigValidator) Validate(config *Config) error {
 	}
 	
 	// Start and end dates are not required for list-templates mode
-	if config.Mode != "list-templates" {
+	if config.Mode != "list-templates" && config.Range != "" {
+		if config.StartDate != "" || config.EndDate != "" {
+			return fmt.Errorf("range cannot be combined with start or end dates")
+		}
+		
+		if !datetime.IsValidRange(config.Range) {
+			return fmt.Errorf("unknown range '%s': expected one of %s", config.Range, strings.Join(datetime.RangePresets, ", "))
+		}
+	} else if config.Mode != "list-templates" {
 		if config.StartDate == "" {
 			return fmt.Errorf("start date is required")
 		}
@@ -54,7 +104,7 @@ func (v *ConfigValidator) Validate(config *Config) error {
 	
 	if config.Mode == "template" {
 		if !isValidTemplateGranularity(config.Granularity) {
-			return fmt.Errorf("granularity for templates must be daily")
+			return fmt.Errorf("granularity for templates must be daily, WEEK, MONTH, QUARTER, or YEAR")
 		}
 		
 		if len(config.TemplateIDs) == 0 {
@@ -69,37 +119,216 @@ func (v *ConfigValidator) Validate(config *Config) error {
 		// Only WBA ID and access token are needed
 	} else {
 		if !isValidGranularity(config.Granularity) {
-			return fmt.Errorf("granularity must be HALF_HOUR, DAY, or MONTH")
+			return fmt.Errorf("granularity must be HALF_HOUR, HOUR, DAY, WEEK, MONTH, QUARTER, or YEAR")
+		}
+	}
+	
+	if config.Compare != "" {
+		if config.Mode == "list-templates" {
+			return fmt.Errorf("compare is only available for analytics and template modes")
+		}
+		
+		if !datetime.IsValidComparison(config.Compare) {
+			return fmt.Errorf("invalid compare '%s': expected previous-period, previous-year or <start>..<end>", config.Compare)
 		}
 	}
 	
-	if config.AccessToken == "" {
-		return fmt.Errorf("access token is required")
+	switch config.View {
+	case "", "table":
+	case "funnel":
+		if config.Mode != "template" {
+			return fmt.Errorf("funnel view is only available in template mode")
+		}
+		if config.Compare != "" {
+			return fmt.Errorf("funnel view cannot be combined with compare")
+		}
+	default:
+		return fmt.Errorf("view must be table or funnel")
+	}
+	
+	switch config.Output {
+	case "", "text":
+	case "markdown", "html":
+		if config.Compare != "" || config.View == "funnel" {
+			return fmt.Errorf("%s output is not available with compare or the funnel view", config.Output)
+		}
+	case "parquet":
+		if config.Mode == "list-templates" {
+			return fmt.Errorf("parquet output is only available in analytics and template modes")
+		}
+		if config.Compare != "" || config.View == "funnel" {
+			return fmt.Errorf("parquet output is not available with compare or the funnel view")
+		}
+		if config.OutFile == "" {
+			return fmt.Errorf("parquet output needs a destination file (-out)")
+		}
+	default:
+		return fmt.Errorf("output must be text, markdown, html or parquet")
+	}
+	
+	if config.OutFile != "" && config.Output != "parquet" {
+		return fmt.Errorf("out is only used with parquet output")
+	}
+	
+	if config.Chart && config.Output != "" && config.Output != "text" {
+		return fmt.Errorf("chart is only available with text output")
+	}
+	
+	if config.TableStyle != "" && config.TableStyle != "box" && config.TableStyle != "ascii" {
+		return fmt.Errorf("table-style must be box or ascii")
+	}
+	
+	switch config.Color {
+	case "", "auto", "always", "never":
+	default:
+		return fmt.Errorf("color must be auto, always or never")
+	}
+	
+	if config.Plain && config.Output != "" && config.Output != "text" {
+		return fmt.Errorf("plain is only available with text output")
+	}
+	
+	if config.CustomFormat != "" {
+		if config.Output != "" && config.Output != "text" {
+			return fmt.Errorf("format-template cannot be combined with %s output", config.Output)
+		}
+		if config.Compare != "" || config.View == "funnel" || config.Chart {
+			return fmt.Errorf("format-template is not available with compare, the funnel view or charts")
+		}
+	}
+	
+	if config.GroupBy != "" {
+		if config.Mode != "template" {
+			return fmt.Errorf("group-by is only available in template mode")
+		}
+		if config.GroupBy != "template" && config.GroupBy != "date" {
+			return fmt.Errorf("group-by must be template or date")
+		}
+	}
+	
+	if config.Sort != "" && !isValidSort(config.Mode, config.Sort) {
+		return fmt.Errorf("invalid sort '%s' for %s mode", config.Sort, config.Mode)
+	}
+	
+	if config.CacheTTL < 0 {
+		return fmt.Errorf("cache-ttl cannot be negative")
+	}
+	
+	if config.NoCache && config.Refresh {
+		return fmt.Errorf("no-cache and refresh cannot be used together")
+	}
+	
+	if config.Currency != "" && !isValidCurrency(config.Currency) {
+		return fmt.Errorf("currency must be a 3-letter ISO 4217 code such as USD or BRL")
+	}
+	
+	if config.Numbers != "" && config.Numbers != "exact" && config.Numbers != "short" {
+		return fmt.Errorf("numbers must be exact or short")
+	}
+	
+	if _, err := datetime.ParseWeekStart(config.WeekStart); err != nil {
+		return err
+	}
+	
+	if config.InputTimezone != "" {
+		if _, err := time.LoadLocation(config.InputTimezone); err != nil {
+			return fmt.Errorf("invalid input timezone '%s': %v", config.InputTimezone, err)
+		}
+	}
+	
+	if config.Timeout < 0 || config.ReqTimeout < 0 {
+		return fmt.Errorf("timeouts cannot be negative")
+	}
+	
+	if len(config.Accounts) > 1 {
+		if config.Mode == "list-templates" {
+			return fmt.Errorf("multiple WABAs are only available in analytics and template modes")
+		}
+		if config.Compare != "" {
+			return fmt.Errorf("multiple WABAs cannot be combined with compare")
+		}
+		if (config.Output != "" && config.Output != "text") || config.CustomFormat != "" {
+			return fmt.Errorf("multiple WABAs are only available with text output")
+		}
+		if config.Concurrency < 1 {
+			return fmt.Errorf("concurrency must be at least 1")
+		}
+	}
+	
+	switch config.Source {
+	case "", "api":
+		if config.AccessToken == "" {
+			return fmt.Errorf("access token is required")
+		}
+	case "local":
+		if config.Mode == "list-templates" {
+			return fmt.Errorf("source local is only available in analytics and template modes")
+		}
+		if config.Granularity == "HALF_HOUR" || config.Granularity == "HOUR" {
+			return fmt.Errorf("source local stores daily data; %s granularity needs the API", config.Granularity)
+		}
+	default:
+		return fmt.Errorf("source must be api or local")
 	}
 	
 	return nil
 }
 
-// isValidGranularity validates the granularity value
+// isValidGranularity validates the granularity value; HOUR, WEEK, QUARTER
+// and YEAR are rolled up locally from finer API data
 func isValidGranularity(g string) bool {
 	switch g {
-	case "HALF_HOUR", "DAY", "MONTH":
+	case "HALF_HOUR", "HOUR", "DAY", "WEEK", "MONTH", "QUARTER", "YEAR":
 		return true
 	default:
 		return false
 	}
 }
 
-// isValidTemplateGranularity validates the granularity value for templates
+// isValidTemplateGranularity validates the granularity value for templates;
+// anything coarser than daily is rolled up locally
 func isValidTemplateGranularity(g string) bool {
 	switch g {
-	case "daily":
+	case "daily", "WEEK", "MONTH", "QUARTER", "YEAR":
 		return true
 	default:
 		return false
 	}
 }
 
+// isValidSort validates the table sort key for a mode
+func isValidSort(mode, sortBy string) bool {
+	var keys []string
+	switch mode {
+	case "template":
+		keys = []string{"date", "template", "sent", "delivered", "read", "clicks", "cost"}
+	case "list-templates":
+		keys = []string{"name", "status"}
+	default:
+		keys = []string{"date", "sent", "delivered"}
+	}
+	
+	for _, key := range keys {
+		if sortBy == key {
+			return true
+		}
+	}
+	return false
+}
+
+// isValidCurrency checks for a 3-letter currency code
+func isValidCurrency(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if (r < 'A' || r > 'Z') && (r < 'a' || r > 'z') {
+			return false
+		}
+	}
+	return true
+}
+
 // LoadAccessToken loads the access token from environment or prompts for it
 func LoadAccessToken(promptFunc func() (string, error)) (string, error) {
 	accessToken := os.Getenv("FB_ACCESS_TOKEN")
@@ -108,4 +337,9 @@ func LoadAccessToken(promptFunc func() (string, error)) (string, error) {
 	}
 	
 	return promptFunc()
-}
\ No newline at end of file
+}
+
+// LoadAppSecret loads the optional app secret used to sign requests with appsecret_proof
+func LoadAppSecret() string {
+	return os.Getenv("FB_APP_SECRET")
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"testing"
+	"time"
 )
 
 func TestConfigValidator_Validate(t *testing.T) {
@@ -54,6 +55,251 @@ func TestConfigValidator_Validate(t *testing.T) {
 			},
 			hasError: true,
 		},
+		{
+			name: "Valid range preset",
+			config: &Config{
+				WBAID:       "123456789",
+				Range:       "last-7-days",
+				Granularity: "DAY",
+				AccessToken: "token123",
+			},
+			hasError: false,
+		},
+		{
+			name: "Unknown range preset",
+			config: &Config{
+				WBAID:       "123456789",
+				Range:       "last-fortnight",
+				Granularity: "DAY",
+				AccessToken: "token123",
+			},
+			hasError: true,
+		},
+		{
+			name: "Range combined with start date",
+			config: &Config{
+				WBAID:       "123456789",
+				StartDate:   "2025-06-20",
+				Range:       "last-month",
+				Granularity: "DAY",
+				AccessToken: "token123",
+			},
+			hasError: true,
+		},
+		{
+			name: "Compare with previous period",
+			config: &Config{
+				WBAID:       "123456789",
+				StartDate:   "2025-06-20",
+				EndDate:     "2025-06-24",
+				Granularity: "DAY",
+				Compare:     "previous-period",
+				AccessToken: "token123",
+			},
+			hasError: false,
+		},
+		{
+			name: "Invalid compare",
+			config: &Config{
+				WBAID:       "123456789",
+				StartDate:   "2025-06-20",
+				EndDate:     "2025-06-24",
+				Granularity: "DAY",
+				Compare:     "last-tuesday",
+				AccessToken: "token123",
+			},
+			hasError: true,
+		},
+		{
+			name: "Invalid numbers style",
+			config: &Config{
+				WBAID:       "123456789",
+				StartDate:   "2025-06-20",
+				EndDate:     "2025-06-24",
+				Granularity: "DAY",
+				Numbers:     "rounded",
+				AccessToken: "token123",
+			},
+			hasError: true,
+		},
+		{
+			name: "Markdown output",
+			config: &Config{
+				WBAID:       "123456789",
+				StartDate:   "2025-06-20",
+				EndDate:     "2025-06-24",
+				Granularity: "DAY",
+				Output:      "markdown",
+				AccessToken: "token123",
+			},
+			hasError: false,
+		},
+		{
+			name: "Invalid color mode",
+			config: &Config{
+				WBAID:       "123456789",
+				StartDate:   "2025-06-20",
+				EndDate:     "2025-06-24",
+				Granularity: "DAY",
+				Color:       "sometimes",
+				AccessToken: "token123",
+			},
+			hasError: true,
+		},
+		{
+			name: "Plain with HTML output",
+			config: &Config{
+				WBAID:       "123456789",
+				StartDate:   "2025-06-20",
+				EndDate:     "2025-06-24",
+				Granularity: "DAY",
+				Output:      "html",
+				Plain:       true,
+				AccessToken: "token123",
+			},
+			hasError: true,
+		},
+		{
+			name: "Format template with markdown output",
+			config: &Config{
+				WBAID:        "123456789",
+				StartDate:    "2025-06-20",
+				EndDate:      "2025-06-24",
+				Granularity:  "DAY",
+				Output:       "markdown",
+				CustomFormat: "{{.Totals.Sent}}",
+				AccessToken:  "token123",
+			},
+			hasError: true,
+		},
+		{
+			name: "No cache with refresh",
+			config: &Config{
+				WBAID:       "123456789",
+				StartDate:   "2025-06-20",
+				EndDate:     "2025-06-24",
+				Granularity: "DAY",
+				NoCache:     true,
+				Refresh:     true,
+				AccessToken: "token123",
+			},
+			hasError: true,
+		},
+		{
+			name: "HTML output with compare",
+			config: &Config{
+				WBAID:       "123456789",
+				StartDate:   "2025-06-20",
+				EndDate:     "2025-06-24",
+				Granularity: "DAY",
+				Output:      "html",
+				Compare:     "previous-period",
+				AccessToken: "token123",
+			},
+			hasError: true,
+		},
+		{
+			name: "Parquet output with destination",
+			config: &Config{
+				WBAID:       "123456789",
+				StartDate:   "2025-06-20",
+				EndDate:     "2025-06-24",
+				Granularity: "DAY",
+				Output:      "parquet",
+				OutFile:     "analytics.parquet",
+				AccessToken: "token123",
+			},
+			hasError: false,
+		},
+		{
+			name: "Parquet output without destination",
+			config: &Config{
+				WBAID:       "123456789",
+				StartDate:   "2025-06-20",
+				EndDate:     "2025-06-24",
+				Granularity: "DAY",
+				Output:      "parquet",
+				AccessToken: "token123",
+			},
+			hasError: true,
+		},
+		{
+			name: "Negative request timeout",
+			config: &Config{
+				WBAID:       "123456789",
+				StartDate:   "2025-06-20",
+				EndDate:     "2025-06-24",
+				Granularity: "DAY",
+				ReqTimeout:  -time.Second,
+				AccessToken: "token123",
+			},
+			hasError: true,
+		},
+		{
+			name: "Multiple WABAs",
+			config: &Config{
+				WBAID:       "123456789",
+				Accounts:    []string{"123456789", "987654321"},
+				Concurrency: 4,
+				StartDate:   "2025-06-20",
+				EndDate:     "2025-06-24",
+				Granularity: "DAY",
+				AccessToken: "token123",
+			},
+			hasError: false,
+		},
+		{
+			name: "Multiple WABAs with compare",
+			config: &Config{
+				WBAID:       "123456789",
+				Accounts:    []string{"123456789", "987654321"},
+				Concurrency: 4,
+				StartDate:   "2025-06-20",
+				EndDate:     "2025-06-24",
+				Granularity: "DAY",
+				Compare:     "previous-period",
+				AccessToken: "token123",
+			},
+			hasError: true,
+		},
+		{
+			name: "Multiple WABAs with HTML output",
+			config: &Config{
+				WBAID:       "123456789",
+				Accounts:    []string{"123456789", "987654321"},
+				Concurrency: 4,
+				StartDate:   "2025-06-20",
+				EndDate:     "2025-06-24",
+				Granularity: "DAY",
+				Output:      "html",
+				AccessToken: "token123",
+			},
+			hasError: true,
+		},
+		{
+			name: "Multiple WABAs without concurrency",
+			config: &Config{
+				WBAID:       "123456789",
+				Accounts:    []string{"123456789", "987654321"},
+				StartDate:   "2025-06-20",
+				EndDate:     "2025-06-24",
+				Granularity: "DAY",
+				AccessToken: "token123",
+			},
+			hasError: true,
+		},
+		{
+			name: "Destination file with text output",
+			config: &Config{
+				WBAID:       "123456789",
+				StartDate:   "2025-06-20",
+				EndDate:     "2025-06-24",
+				Granularity: "DAY",
+				OutFile:     "analytics.parquet",
+				AccessToken: "token123",
+			},
+			hasError: true,
+		},
 		{
 			name: "Missing access token",
 			config: &Config{
@@ -64,6 +310,40 @@ func TestConfigValidator_Validate(t *testing.T) {
 			},
 			hasError: true,
 		},
+		{
+			name: "Local source without access token",
+			config: &Config{
+				WBAID:       "123456789",
+				StartDate:   "2025-06-20",
+				EndDate:     "2025-06-24",
+				Granularity: "DAY",
+				Source:      "local",
+			},
+			hasError: false,
+		},
+		{
+			name: "Local source with hourly granularity",
+			config: &Config{
+				WBAID:       "123456789",
+				StartDate:   "2025-06-20",
+				EndDate:     "2025-06-24",
+				Granularity: "HOUR",
+				Source:      "local",
+			},
+			hasError: true,
+		},
+		{
+			name: "Invalid source",
+			config: &Config{
+				WBAID:       "123456789",
+				StartDate:   "2025-06-20",
+				EndDate:     "2025-06-24",
+				Granularity: "DAY",
+				Source:      "remote",
+				AccessToken: "token123",
+			},
+			hasError: true,
+		},
 	}
 
 	for _, tt := range tests {
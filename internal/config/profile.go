@@ -0,0 +1,64 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultProfilesPath returns the profiles file under the user config directory
+func DefaultProfilesPath() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("could not locate the user config directory: %w", err)
+	}
+	return filepath.Join(base, "wppanalyticscli", "profiles.json"), nil
+}
+
+// LoadProfile returns the WABA IDs of a named profile. The file maps profile
+// names to lists of WABA IDs, e.g. {"brands": ["123", "456"]}.
+func LoadProfile(path, name string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profiles: %w", err)
+	}
+
+	var profiles map[string][]string
+	if err := json.Unmarshal(data, &profiles); err != nil {
+		return nil, fmt.Errorf("invalid profiles file %s: %w", path, err)
+	}
+
+	wbaIDs, ok := profiles[name]
+	if !ok {
+		names := make([]string, 0, len(profiles))
+		for profile := range profiles {
+			names = append(names, profile)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown profile '%s': expected one of %s", name, strings.Join(names, ", "))
+	}
+
+	accounts := ParseAccounts(strings.Join(wbaIDs, ","))
+	if len(accounts) == 0 {
+		return nil, fmt.Errorf("profile '%s' has no WABA IDs", name)
+	}
+	return accounts, nil
+}
+
+// ParseAccounts splits a comma-separated list of WABA IDs, dropping blanks and duplicates
+func ParseAccounts(value string) []string {
+	var accounts []string
+	seen := make(map[string]bool)
+	for _, wbaID := range strings.Split(value, ",") {
+		wbaID = strings.TrimSpace(wbaID)
+		if wbaID == "" || seen[wbaID] {
+			continue
+		}
+		seen[wbaID] = true
+		accounts = append(accounts, wbaID)
+	}
+	return accounts
+}
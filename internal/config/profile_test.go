@@ -0,0 +1,55 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseAccounts(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected []string
+	}{
+		{"123", []string{"123"}},
+		{"123, 456,,123", []string{"123", "456"}},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		if got := ParseAccounts(tt.value); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("ParseAccounts(%q): expected %v, got %v", tt.value, tt.expected, got)
+		}
+	}
+}
+
+func TestLoadProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles.json")
+	content := `{"brands": ["123", " 456 ", "123"], "empty": [], "retail": ["789"]}`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	accounts, err := LoadProfile(path, "brands")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(accounts, []string{"123", "456"}) {
+		t.Errorf("Expected [123 456], got %v", accounts)
+	}
+
+	if _, err := LoadProfile(path, "empty"); err == nil {
+		t.Error("Expected error for a profile without WABA IDs")
+	}
+
+	_, err = LoadProfile(path, "missing")
+	if err == nil || !strings.Contains(err.Error(), "brands, empty, retail") {
+		t.Errorf("Expected unknown profile error listing the profiles, got %v", err)
+	}
+
+	if _, err := LoadProfile(filepath.Join(t.TempDir(), "none.json"), "brands"); err == nil {
+		t.Error("Expected error for a missing profiles file")
+	}
+}
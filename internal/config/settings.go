@@ -0,0 +1,73 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Settings are the defaults read from the settings file, e.g.
+// {"api_base_url": "http://localhost:8080", "api_version": "v23.0"}
+type Settings struct {
+	APIBaseURL string `json:"api_base_url"`
+	APIVersion string `json:"api_version"`
+}
+
+// DefaultSettingsPath returns the settings file under the user config directory,
+// next to profiles.json
+func DefaultSettingsPath() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("could not locate the user config directory: %w", err)
+	}
+	return filepath.Join(base, "wppanalyticscli", "config.json"), nil
+}
+
+// LoadSettings reads the settings file at path; a missing file yields empty settings
+func LoadSettings(path string) (*Settings, error) {
+	settings := &Settings{}
+	if path == "" {
+		return settings, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return settings, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read settings: %w", err)
+	}
+	if err := json.Unmarshal(data, settings); err != nil {
+		return nil, fmt.Errorf("invalid settings file %s: %w", path, err)
+	}
+	return settings, nil
+}
+
+// LoadAPIEndpoint resolves the Graph API base URL and version with the precedence
+// flag, then FB_API_BASE_URL/FB_API_VERSION, then the settings file at
+// settingsPath. Values still empty are left for the caller's defaults.
+func LoadAPIEndpoint(baseURL, version, settingsPath string) (string, string, error) {
+	if baseURL == "" {
+		baseURL = os.Getenv("FB_API_BASE_URL")
+	}
+	if version == "" {
+		version = os.Getenv("FB_API_VERSION")
+	}
+	if baseURL != "" && version != "" {
+		return baseURL, version, nil
+	}
+
+	settings, err := LoadSettings(settingsPath)
+	if err != nil {
+		return "", "", err
+	}
+	if baseURL == "" {
+		baseURL = settings.APIBaseURL
+	}
+	if version == "" {
+		version = settings.APIVersion
+	}
+	return baseURL, version, nil
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadAPIEndpoint(t *testing.T) {
+	settingsPath := filepath.Join(t.TempDir(), "config.json")
+	content := `{"api_base_url": "http://settings:8080", "api_version": "v20.0"}`
+	if err := os.WriteFile(settingsPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name            string
+		flagBaseURL     string
+		flagVersion     string
+		envBaseURL      string
+		envVersion      string
+		settingsPath    string
+		expectedBaseURL string
+		expectedVersion string
+	}{
+		{"Flags override everything", "http://flag:9000", "v22.0", "http://env:8080", "v21.0", settingsPath, "http://flag:9000", "v22.0"},
+		{"Environment overrides settings", "", "", "http://env:8080", "v21.0", settingsPath, "http://env:8080", "v21.0"},
+		{"Settings file", "", "", "", "", settingsPath, "http://settings:8080", "v20.0"},
+		{"Mixed sources", "", "v22.0", "http://env:8080", "", settingsPath, "http://env:8080", "v22.0"},
+		{"Missing settings file", "", "", "", "", filepath.Join(t.TempDir(), "missing.json"), "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("FB_API_BASE_URL", tt.envBaseURL)
+			t.Setenv("FB_API_VERSION", tt.envVersion)
+
+			baseURL, version, err := LoadAPIEndpoint(tt.flagBaseURL, tt.flagVersion, tt.settingsPath)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if baseURL != tt.expectedBaseURL || version != tt.expectedVersion {
+				t.Errorf("Expected '%s' '%s', got '%s' '%s'", tt.expectedBaseURL, tt.expectedVersion, baseURL, version)
+			}
+		})
+	}
+}
+
+func TestLoadSettings_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"api_version": `), 0600); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := LoadSettings(path); err == nil {
+		t.Errorf("Expected error for a malformed settings file, got none")
+	}
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"wppanalyticscli/internal/models"
+)
+
+// RequiredTokenScope is the permission needed to read WhatsApp Business analytics
+const RequiredTokenScope = "whatsapp_business_management"
+
+// DefaultTokenWarnDays is how many days ahead of expiry the token check starts warning
+const DefaultTokenWarnDays = 7
+
+// CheckToken inspects debug_token data and returns warnings about upcoming expiry
+// or restricted scopes. An error is returned when the token cannot be used at all.
+func CheckToken(data *models.TokenDebugData, wbaID string, now time.Time, warnDays int) ([]string, error) {
+	var warnings []string
+
+	if !data.IsValid {
+		if data.Error != nil && data.Error.Message != "" {
+			return nil, fmt.Errorf("access token is invalid: %s", data.Error.Message)
+		}
+		return nil, fmt.Errorf("access token is invalid")
+	}
+
+	warnWindow := time.Duration(warnDays) * 24 * time.Hour
+
+	if data.ExpiresAt > 0 {
+		expiresAt := time.Unix(data.ExpiresAt, 0)
+		if !expiresAt.After(now) {
+			return nil, fmt.Errorf("access token expired on %s", expiresAt.UTC().Format(time.RFC3339))
+		}
+		if expiresAt.Sub(now) <= warnWindow {
+			warnings = append(warnings, fmt.Sprintf("access token expires in %s (%s)",
+				formatRemaining(expiresAt.Sub(now)), expiresAt.UTC().Format(time.RFC3339)))
+		}
+	}
+
+	if data.DataAccessExpiresAt > 0 {
+		dataAccessExpiresAt := time.Unix(data.DataAccessExpiresAt, 0)
+		if !dataAccessExpiresAt.After(now) {
+			return nil, fmt.Errorf("data access expired on %s", dataAccessExpiresAt.UTC().Format(time.RFC3339))
+		}
+		if dataAccessExpiresAt.Sub(now) <= warnWindow {
+			warnings = append(warnings, fmt.Sprintf("data access expires in %s (%s)",
+				formatRemaining(dataAccessExpiresAt.Sub(now)), dataAccessExpiresAt.UTC().Format(time.RFC3339)))
+		}
+	}
+
+	if !hasScope(data.Scopes, RequiredTokenScope) {
+		return nil, fmt.Errorf("access token lacks the %s permission", RequiredTokenScope)
+	}
+
+	// Granular scopes list the WABAs the permission was granted for, possibly
+	// spread over several entries; warn once when none of them covers wbaID
+	if wbaID != "" {
+		var targets []string
+		for _, granular := range data.GranularScopes {
+			if granular.Scope == RequiredTokenScope {
+				targets = append(targets, granular.TargetIDs...)
+			}
+		}
+		if len(targets) > 0 && !hasScope(targets, wbaID) {
+			warnings = append(warnings, fmt.Sprintf("%s is not granted for WBA ID %s", RequiredTokenScope, wbaID))
+		}
+	}
+
+	return warnings, nil
+}
+
+// hasScope reports whether value is present in list
+func hasScope(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// formatRemaining renders a duration in days, or hours when under a day
+func formatRemaining(d time.Duration) string {
+	if d < 24*time.Hour {
+		return fmt.Sprintf("%d hours", int(d.Hours()))
+	}
+	return fmt.Sprintf("%d days", int(d.Hours()/24))
+}
@@ -0,0 +1,142 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"wppanalyticscli/internal/models"
+)
+
+func TestCheckToken(t *testing.T) {
+	now := time.Date(2025, 6, 20, 12, 0, 0, 0, time.UTC)
+	day := int64(24 * 60 * 60)
+
+	tests := []struct {
+		name         string
+		data         models.TokenDebugData
+		wbaID        string
+		warnings     int
+		hasError     bool
+		errorContain string
+	}{
+		{
+			name: "Valid system user token that never expires",
+			data: models.TokenDebugData{
+				IsValid: true,
+				Type:    "SYSTEM_USER",
+				Scopes:  []string{"whatsapp_business_management"},
+			},
+			warnings: 0,
+		},
+		{
+			name: "Expires within warning window",
+			data: models.TokenDebugData{
+				IsValid:   true,
+				ExpiresAt: now.Unix() + 3*day,
+				Scopes:    []string{"whatsapp_business_management"},
+			},
+			warnings: 1,
+		},
+		{
+			name: "Data access expires within warning window",
+			data: models.TokenDebugData{
+				IsValid:             true,
+				ExpiresAt:           now.Unix() + 60*day,
+				DataAccessExpiresAt: now.Unix() + 2*day,
+				Scopes:              []string{"whatsapp_business_management"},
+			},
+			warnings: 1,
+		},
+		{
+			name: "Already expired",
+			data: models.TokenDebugData{
+				IsValid:   true,
+				ExpiresAt: now.Unix() - day,
+				Scopes:    []string{"whatsapp_business_management"},
+			},
+			hasError:     true,
+			errorContain: "expired",
+		},
+		{
+			name: "Missing scope",
+			data: models.TokenDebugData{
+				IsValid: true,
+				Scopes:  []string{"business_management"},
+			},
+			hasError:     true,
+			errorContain: RequiredTokenScope,
+		},
+		{
+			name: "Invalid token",
+			data: models.TokenDebugData{
+				IsValid: false,
+				Error:   &models.TokenDebugError{Code: 190, Message: "Session has expired"},
+			},
+			hasError:     true,
+			errorContain: "Session has expired",
+		},
+		{
+			name: "WABA not in granular scope",
+			data: models.TokenDebugData{
+				IsValid: true,
+				Scopes:  []string{"whatsapp_business_management"},
+				GranularScopes: []models.GranularScope{
+					{Scope: "whatsapp_business_management", TargetIDs: []string{"111"}},
+				},
+			},
+			wbaID:    "932157148829117",
+			warnings: 1,
+		},
+		{
+			name: "WABA missing from several granular entries",
+			data: models.TokenDebugData{
+				IsValid: true,
+				Scopes:  []string{"whatsapp_business_management"},
+				GranularScopes: []models.GranularScope{
+					{Scope: "whatsapp_business_management", TargetIDs: []string{"111"}},
+					{Scope: "whatsapp_business_management", TargetIDs: []string{"222"}},
+				},
+			},
+			wbaID:    "932157148829117",
+			warnings: 1,
+		},
+		{
+			name: "WABA in a later granular entry",
+			data: models.TokenDebugData{
+				IsValid: true,
+				Scopes:  []string{"whatsapp_business_management"},
+				GranularScopes: []models.GranularScope{
+					{Scope: "whatsapp_business_management", TargetIDs: []string{"111"}},
+					{Scope: "whatsapp_business_management", TargetIDs: []string{"932157148829117"}},
+				},
+			},
+			wbaID:    "932157148829117",
+			warnings: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := CheckToken(&tt.data, tt.wbaID, now, DefaultTokenWarnDays)
+
+			if tt.hasError {
+				if err == nil {
+					t.Fatalf("Expected error but got none")
+				}
+				if !strings.Contains(err.Error(), tt.errorContain) {
+					t.Errorf("Expected error containing '%s', got '%v'", tt.errorContain, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if len(warnings) != tt.warnings {
+				t.Errorf("Expected %d warnings, got %d: %v", tt.warnings, len(warnings), warnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"wppanalyticscli/internal/datetime"
+	"wppanalyticscli/internal/rollup"
+)
+
+// MaxHalfHourSpan is the longest window accepted at HALF_HOUR granularity
+const MaxHalfHourSpan = 31 * 24 * time.Hour
+
+// MaxTemplateSpan is the longest window the template analytics endpoint accepts
+const MaxTemplateSpan = 90 * 24 * time.Hour
+
+// MaxLookback is roughly how far back the Graph API retains analytics data
+const MaxLookback = 365 * 24 * time.Hour
+
+// WindowCheck is the outcome of validating a date window against a granularity
+type WindowCheck struct {
+	Start time.Time
+	End   time.Time
+	Notes []string // Adjustments made or caveats about the window
+}
+
+// ValidateWindow checks that [start, end) makes sense for the configured mode and
+// granularity. Boundaries are evaluated in the location of start and end, which
+// callers pass in the report timezone even when the dates were typed in an
+// input timezone, since buckets are cut there. When config.Align is set, misaligned windows are snapped to granularity boundaries
+// and each adjustment is explained in the returned notes.
+func (v *ConfigValidator) ValidateWindow(config *Config, start, end time.Time) (*WindowCheck, error) {
+	check := &WindowCheck{Start: start, End: end}
+
+	if !end.After(start) {
+		return nil, fmt.Errorf("end date %s must be after start date %s", formatWindowTime(end), formatWindowTime(start))
+	}
+
+	granularity := windowGranularity(config)
+	weekStart, err := datetime.ParseWeekStart(config.WeekStart)
+	if err != nil {
+		return nil, err
+	}
+
+	if !datetime.IsAligned(start, granularity, weekStart) || !datetime.IsAligned(end, granularity, weekStart) {
+		if config.Align {
+			alignedStart, alignedEnd := datetime.AlignWindow(start, end, granularity, weekStart)
+			if !alignedStart.Equal(start) {
+				check.Notes = append(check.Notes, fmt.Sprintf("start moved from %s to %s to align with %s buckets",
+					formatWindowTime(start), formatWindowTime(alignedStart), granularity))
+			}
+			if !alignedEnd.Equal(end) {
+				check.Notes = append(check.Notes, fmt.Sprintf("end moved from %s to %s to align with %s buckets",
+					formatWindowTime(end), formatWindowTime(alignedEnd), granularity))
+			}
+			check.Start, check.End = alignedStart, alignedEnd
+		} else if granularity == "MONTH" && config.Mode != "template" {
+			return nil, fmt.Errorf("MONTH granularity needs a window starting and ending on the first day of a month in %s%s (use -align to snap %s - %s)",
+				start.Location(), boundaryZoneNote(config, start.Location()), formatWindowTime(start), formatWindowTime(end))
+		} else {
+			check.Notes = append(check.Notes, fmt.Sprintf("window %s - %s is not aligned to %s boundaries; the first or last bucket will be partial (use -align to snap)",
+				formatWindowTime(start), formatWindowTime(end), granularity))
+		}
+	}
+
+	span := check.End.Sub(check.Start)
+	if rollup.SourceGranularity(config.Mode, config.Granularity) == "HALF_HOUR" && span > MaxHalfHourSpan {
+		return nil, fmt.Errorf("HALF_HOUR granularity supports windows up to %d days, got %.1f days; use DAY or MONTH",
+			int(MaxHalfHourSpan.Hours()/24), span.Hours()/24)
+	}
+
+	// The local history has no span or retention limits
+	if config.Source == "local" {
+		return check, nil
+	}
+
+	// Coarser template granularities are fetched in TemplateChunks and rolled up locally
+	if config.Mode == "template" && !rollup.IsTemplateClientSide(config.Granularity) && span > MaxTemplateSpan {
+		return nil, fmt.Errorf("template analytics supports windows up to %d days, got %.1f days",
+			int(MaxTemplateSpan.Hours()/24), span.Hours()/24)
+	}
+
+	if v.now().Sub(check.Start) > MaxLookback {
+		return nil, fmt.Errorf("start date %s is more than %d days ago, beyond what the API retains",
+			formatWindowTime(check.Start), int(MaxLookback.Hours()/24))
+	}
+
+	return check, nil
+}
+
+// TemplateChunks splits [start, end) into consecutive windows the template
+// analytics endpoint accepts: at most MaxTemplateSpan long, with every inner
+// boundary at midnight in the location of start
+func TemplateChunks(start, end time.Time) [][2]time.Time {
+	maxDays := int(MaxTemplateSpan.Hours() / 24)
+	loc := start.Location()
+
+	var chunks [][2]time.Time
+	for chunkStart := start; chunkStart.Before(end); {
+		day := time.Date(chunkStart.Year(), chunkStart.Month(), chunkStart.Day(), 0, 0, 0, 0, loc)
+		chunkEnd := day.AddDate(0, 0, maxDays)
+		// A daylight saving change can make 90 calendar days an hour too long
+		for chunkEnd.Sub(chunkStart) > MaxTemplateSpan {
+			chunkEnd = chunkEnd.AddDate(0, 0, -1)
+		}
+		if chunkEnd.After(end) {
+			chunkEnd = end
+		}
+		chunks = append(chunks, [2]time.Time{chunkStart, chunkEnd})
+		chunkStart = chunkEnd
+	}
+	return chunks
+}
+
+// windowGranularity maps the configured granularity to the boundaries used for alignment
+func windowGranularity(config *Config) string {
+	if config.Mode == "template" && !rollup.IsTemplateClientSide(config.Granularity) {
+		return "DAY"
+	}
+	return strings.ToUpper(config.Granularity)
+}
+
+// boundaryZoneNote explains that bucket boundaries are checked in -timezone,
+// pointing out when the dates were typed in a different -input-timezone
+func boundaryZoneNote(config *Config, loc *time.Location) string {
+	if config.InputTimezone != "" && config.InputTimezone != loc.String() {
+		return fmt.Sprintf(" (-timezone), not in %s (-input-timezone) where the dates were read", config.InputTimezone)
+	}
+	return " (-timezone)"
+}
+
+// formatWindowTime formats a window boundary in its own location
+func formatWindowTime(t time.Time) string {
+	return t.Format("2006-01-02 15:04 MST")
+}
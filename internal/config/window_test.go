@@ -0,0 +1,187 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfigValidator_ValidateWindow(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Sao_Paulo")
+	validator := NewConfigValidator()
+	validator.now = func() time.Time {
+		return time.Date(2025, 7, 1, 12, 0, 0, 0, loc)
+	}
+
+	tests := []struct {
+		name          string
+		config        *Config
+		start         time.Time
+		end           time.Time
+		expectedStart time.Time
+		expectedEnd   time.Time
+		notes         int
+		errorContain  string
+	}{
+		{
+			name:          "Aligned daily window",
+			config:        &Config{Mode: "analytics", Granularity: "DAY"},
+			start:         time.Date(2025, 6, 20, 0, 0, 0, 0, loc),
+			end:           time.Date(2025, 6, 25, 0, 0, 0, 0, loc),
+			expectedStart: time.Date(2025, 6, 20, 0, 0, 0, 0, loc),
+			expectedEnd:   time.Date(2025, 6, 25, 0, 0, 0, 0, loc),
+		},
+		{
+			name:         "End before start",
+			config:       &Config{Mode: "analytics", Granularity: "DAY"},
+			start:        time.Date(2025, 6, 25, 0, 0, 0, 0, loc),
+			end:          time.Date(2025, 6, 20, 0, 0, 0, 0, loc),
+			errorContain: "must be after start date",
+		},
+		{
+			name:         "Half hour over months",
+			config:       &Config{Mode: "analytics", Granularity: "HALF_HOUR"},
+			start:        time.Date(2025, 3, 1, 0, 0, 0, 0, loc),
+			end:          time.Date(2025, 6, 1, 0, 0, 0, 0, loc),
+			errorContain: "HALF_HOUR granularity supports windows up to 31 days",
+		},
+		{
+			name:         "Month with mid-month start",
+			config:       &Config{Mode: "analytics", Granularity: "MONTH"},
+			start:        time.Date(2025, 3, 15, 0, 0, 0, 0, loc),
+			end:          time.Date(2025, 6, 1, 0, 0, 0, 0, loc),
+			errorContain: "-align",
+		},
+		{
+			name:          "Month with mid-month start aligned",
+			config:        &Config{Mode: "analytics", Granularity: "MONTH", Align: true},
+			start:         time.Date(2025, 3, 15, 0, 0, 0, 0, loc),
+			end:           time.Date(2025, 5, 20, 0, 0, 0, 0, loc),
+			expectedStart: time.Date(2025, 3, 1, 0, 0, 0, 0, loc),
+			expectedEnd:   time.Date(2025, 6, 1, 0, 0, 0, 0, loc),
+			notes:         2,
+		},
+		{
+			name:         "Month typed in another input timezone",
+			config:       &Config{Mode: "analytics", Granularity: "MONTH", InputTimezone: "UTC"},
+			start:        time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC).In(loc),
+			end:          time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC).In(loc),
+			errorContain: "month in America/Sao_Paulo (-timezone), not in UTC (-input-timezone)",
+		},
+		{
+			name:          "Month typed in another input timezone aligned",
+			config:        &Config{Mode: "analytics", Granularity: "MONTH", InputTimezone: "UTC", Align: true},
+			start:         time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC).In(loc),
+			end:           time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC).In(loc),
+			expectedStart: time.Date(2025, 2, 1, 0, 0, 0, 0, loc),
+			expectedEnd:   time.Date(2025, 6, 1, 0, 0, 0, 0, loc),
+			notes:         2,
+		},
+		{
+			name:          "Misaligned daily window without align",
+			config:        &Config{Mode: "analytics", Granularity: "DAY"},
+			start:         time.Date(2025, 6, 20, 10, 0, 0, 0, loc),
+			end:           time.Date(2025, 6, 25, 0, 0, 0, 0, loc),
+			expectedStart: time.Date(2025, 6, 20, 10, 0, 0, 0, loc),
+			expectedEnd:   time.Date(2025, 6, 25, 0, 0, 0, 0, loc),
+			notes:         1,
+		},
+		{
+			name:         "Template window too long",
+			config:       &Config{Mode: "template", Granularity: "daily"},
+			start:        time.Date(2025, 1, 1, 0, 0, 0, 0, loc),
+			end:          time.Date(2025, 6, 1, 0, 0, 0, 0, loc),
+			errorContain: "template analytics supports windows up to 90 days",
+		},
+		{
+			name:          "Template quarter from the API",
+			config:        &Config{Mode: "template", Granularity: "QUARTER"},
+			start:         time.Date(2025, 4, 1, 0, 0, 0, 0, loc),
+			end:           time.Date(2025, 7, 1, 0, 0, 0, 0, loc),
+			expectedStart: time.Date(2025, 4, 1, 0, 0, 0, 0, loc),
+			expectedEnd:   time.Date(2025, 7, 1, 0, 0, 0, 0, loc),
+		},
+		{
+			name:          "Template year from the API",
+			config:        &Config{Mode: "template", Granularity: "YEAR"},
+			start:         time.Date(2025, 1, 1, 0, 0, 0, 0, loc),
+			end:           time.Date(2026, 1, 1, 0, 0, 0, 0, loc),
+			expectedStart: time.Date(2025, 1, 1, 0, 0, 0, 0, loc),
+			expectedEnd:   time.Date(2026, 1, 1, 0, 0, 0, 0, loc),
+		},
+		{
+			name:         "Beyond retention",
+			config:       &Config{Mode: "analytics", Granularity: "MONTH"},
+			start:        time.Date(2023, 1, 1, 0, 0, 0, 0, loc),
+			end:          time.Date(2023, 6, 1, 0, 0, 0, 0, loc),
+			errorContain: "beyond what the API retains",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check, err := validator.ValidateWindow(tt.config, tt.start, tt.end)
+
+			if tt.errorContain != "" {
+				if err == nil {
+					t.Fatalf("Expected error but got none")
+				}
+				if !strings.Contains(err.Error(), tt.errorContain) {
+					t.Errorf("Expected error containing '%s', got '%v'", tt.errorContain, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !check.Start.Equal(tt.expectedStart) || !check.End.Equal(tt.expectedEnd) {
+				t.Errorf("Expected %s - %s, got %s - %s", tt.expectedStart, tt.expectedEnd, check.Start, check.End)
+			}
+
+			if len(check.Notes) != tt.notes {
+				t.Errorf("Expected %d notes, got %d: %v", tt.notes, len(check.Notes), check.Notes)
+			}
+		})
+	}
+}
+
+func TestTemplateChunks(t *testing.T) {
+	loc, _ := time.LoadLocation("America/New_York")
+
+	tests := []struct {
+		name   string
+		start  time.Time
+		end    time.Time
+		chunks int
+	}{
+		{"Within one request", time.Date(2025, 6, 1, 0, 0, 0, 0, loc), time.Date(2025, 7, 1, 0, 0, 0, 0, loc), 1},
+		{"Aligned quarter", time.Date(2025, 4, 1, 0, 0, 0, 0, loc), time.Date(2025, 7, 1, 0, 0, 0, 0, loc), 2},
+		{"Year", time.Date(2025, 1, 1, 0, 0, 0, 0, loc), time.Date(2026, 1, 1, 0, 0, 0, 0, loc), 5},
+		{"Mid-day start", time.Date(2025, 1, 1, 15, 0, 0, 0, loc), time.Date(2025, 4, 15, 0, 0, 0, 0, loc), 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunks := TemplateChunks(tt.start, tt.end)
+			if len(chunks) != tt.chunks {
+				t.Fatalf("Expected %d chunks, got %d: %v", tt.chunks, len(chunks), chunks)
+			}
+			if !chunks[0][0].Equal(tt.start) || !chunks[len(chunks)-1][1].Equal(tt.end) {
+				t.Errorf("Expected chunks to cover %s - %s, got %v", tt.start, tt.end, chunks)
+			}
+			for i, chunk := range chunks {
+				if chunk[1].Sub(chunk[0]) > MaxTemplateSpan {
+					t.Errorf("Chunk %d is longer than %s: %v", i, MaxTemplateSpan, chunk)
+				}
+				if i > 0 && !chunk[0].Equal(chunks[i-1][1]) {
+					t.Errorf("Chunk %d does not start where chunk %d ends", i, i-1)
+				}
+				if i < len(chunks)-1 && (chunk[1].Hour() != 0 || chunk[1].Minute() != 0) {
+					t.Errorf("Chunk %d does not end at midnight: %s", i, chunk[1])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package datetime
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// IsValidComparison reports whether spec is a supported comparison:
+// previous-period, previous-year or an explicit "<start>..<end>" window
+func IsValidComparison(spec string) bool {
+	switch spec {
+	case "previous-period", "previous-year":
+		return true
+	default:
+		parts := strings.Split(spec, "..")
+		return len(parts) == 2 && parts[0] != "" && parts[1] != ""
+	}
+}
+
+// ResolveComparison returns the window to compare [start, end) against.
+//
+// previous-period shifts the window back by its own length, or by whole months when
+// the window spans whole calendar months. previous-year shifts it back one year.
+// "<start>..<end>" is parsed with parser like the -start/-end flags.
+func ResolveComparison(spec string, start, end time.Time, parser Parser) (time.Time, time.Time, error) {
+	switch spec {
+	case "previous-period":
+		if months := wholeMonths(start, end); months > 0 {
+			return start.AddDate(0, -months, 0), start, nil
+		}
+		return start.Add(-end.Sub(start)), start, nil
+	case "previous-year":
+		return start.AddDate(-1, 0, 0), end.AddDate(-1, 0, 0), nil
+	}
+
+	parts := strings.Split(spec, "..")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid comparison '%s': expected previous-period, previous-year or <start>..<end>", spec)
+	}
+
+	compareStart, err := parser.ParseToEpoch(parts[0])
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid comparison start: %w", err)
+	}
+
+	compareEnd, err := parser.ParseToEpoch(parts[1])
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid comparison end: %w", err)
+	}
+
+	if compareEnd <= compareStart {
+		return time.Time{}, time.Time{}, fmt.Errorf("comparison end must be after comparison start")
+	}
+
+	return time.Unix(compareStart, 0).In(start.Location()), time.Unix(compareEnd, 0).In(start.Location()), nil
+}
+
+// wholeMonths returns how many calendar months [start, end) spans, or 0 when
+// either boundary is not the first of a month at midnight
+func wholeMonths(start, end time.Time) int {
+	if !IsAligned(start, "MONTH", time.Monday) || !IsAligned(end, "MONTH", time.Monday) {
+		return 0
+	}
+	return (end.Year()-start.Year())*12 + int(end.Month()) - int(start.Month())
+}
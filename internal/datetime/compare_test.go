@@ -0,0 +1,89 @@
+package datetime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolveComparison(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Sao_Paulo")
+	parser := NewISO8601ParserInLocation(loc)
+
+	tests := []struct {
+		name          string
+		spec          string
+		start         time.Time
+		end           time.Time
+		expectedStart time.Time
+		expectedEnd   time.Time
+		hasError      bool
+	}{
+		{
+			name:          "Previous week",
+			spec:          "previous-period",
+			start:         time.Date(2025, 6, 16, 0, 0, 0, 0, loc),
+			end:           time.Date(2025, 6, 23, 0, 0, 0, 0, loc),
+			expectedStart: time.Date(2025, 6, 9, 0, 0, 0, 0, loc),
+			expectedEnd:   time.Date(2025, 6, 16, 0, 0, 0, 0, loc),
+		},
+		{
+			name:          "Previous calendar month",
+			spec:          "previous-period",
+			start:         time.Date(2025, 3, 1, 0, 0, 0, 0, loc),
+			end:           time.Date(2025, 4, 1, 0, 0, 0, 0, loc),
+			expectedStart: time.Date(2025, 2, 1, 0, 0, 0, 0, loc),
+			expectedEnd:   time.Date(2025, 3, 1, 0, 0, 0, 0, loc),
+		},
+		{
+			name:          "Previous year",
+			spec:          "previous-year",
+			start:         time.Date(2025, 6, 16, 0, 0, 0, 0, loc),
+			end:           time.Date(2025, 6, 23, 0, 0, 0, 0, loc),
+			expectedStart: time.Date(2024, 6, 16, 0, 0, 0, 0, loc),
+			expectedEnd:   time.Date(2024, 6, 23, 0, 0, 0, 0, loc),
+		},
+		{
+			name:          "Explicit window",
+			spec:          "2025-05-01..2025-05-08",
+			start:         time.Date(2025, 6, 16, 0, 0, 0, 0, loc),
+			end:           time.Date(2025, 6, 23, 0, 0, 0, 0, loc),
+			expectedStart: time.Date(2025, 5, 1, 0, 0, 0, 0, loc),
+			expectedEnd:   time.Date(2025, 5, 8, 0, 0, 0, 0, loc),
+		},
+		{
+			name:     "Explicit window reversed",
+			spec:     "2025-05-08..2025-05-01",
+			start:    time.Date(2025, 6, 16, 0, 0, 0, 0, loc),
+			end:      time.Date(2025, 6, 23, 0, 0, 0, 0, loc),
+			hasError: true,
+		},
+		{
+			name:     "Unknown spec",
+			spec:     "last-tuesday",
+			start:    time.Date(2025, 6, 16, 0, 0, 0, 0, loc),
+			end:      time.Date(2025, 6, 23, 0, 0, 0, 0, loc),
+			hasError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := ResolveComparison(tt.spec, tt.start, tt.end, parser)
+
+			if tt.hasError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !start.Equal(tt.expectedStart) || !end.Equal(tt.expectedEnd) {
+				t.Errorf("Expected %s - %s, got %s - %s", tt.expectedStart, tt.expectedEnd, start, end)
+			}
+		})
+	}
+}
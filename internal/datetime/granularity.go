@@ -0,0 +1,90 @@
+package datetime
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TruncateToGranularity returns the start of the granularity bucket containing t, in t's location.
+// Weeks start on weekStart; use time.Monday for ISO weeks.
+func TruncateToGranularity(t time.Time, granularity string, weekStart time.Weekday) time.Time {
+	switch strings.ToUpper(granularity) {
+	case "HALF_HOUR":
+		minute := t.Minute() / 30 * 30
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), minute, 0, 0, t.Location())
+	case "HOUR":
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+	case "WEEK":
+		day := StartOfDay(t)
+		offset := (int(day.Weekday()) - int(weekStart) + 7) % 7
+		return day.AddDate(0, 0, -offset)
+	case "MONTH":
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	case "QUARTER":
+		return StartOfQuarter(t)
+	case "YEAR":
+		return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return StartOfDay(t)
+	}
+}
+
+// NextBoundary returns the start of the granularity bucket following the one containing t
+func NextBoundary(t time.Time, granularity string, weekStart time.Weekday) time.Time {
+	start := TruncateToGranularity(t, granularity, weekStart)
+	switch strings.ToUpper(granularity) {
+	case "HALF_HOUR":
+		return start.Add(30 * time.Minute)
+	case "HOUR":
+		return start.Add(time.Hour)
+	case "WEEK":
+		return start.AddDate(0, 0, 7)
+	case "MONTH":
+		return start.AddDate(0, 1, 0)
+	case "QUARTER":
+		return start.AddDate(0, 3, 0)
+	case "YEAR":
+		return start.AddDate(1, 0, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// IsAligned reports whether t falls exactly on a granularity boundary
+func IsAligned(t time.Time, granularity string, weekStart time.Weekday) bool {
+	return TruncateToGranularity(t, granularity, weekStart).Equal(t)
+}
+
+// AlignWindow widens [start, end) so both ends fall on granularity boundaries:
+// start is moved back to the beginning of its bucket and end forward to the next boundary
+func AlignWindow(start, end time.Time, granularity string, weekStart time.Weekday) (time.Time, time.Time) {
+	alignedStart := TruncateToGranularity(start, granularity, weekStart)
+	alignedEnd := end
+	if !IsAligned(end, granularity, weekStart) {
+		alignedEnd = NextBoundary(end, granularity, weekStart)
+	}
+	return alignedStart, alignedEnd
+}
+
+// ParseWeekStart parses a week start option: "iso" (Monday) or a weekday name
+func ParseWeekStart(s string) (time.Weekday, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "iso", "monday":
+		return time.Monday, nil
+	case "tuesday":
+		return time.Tuesday, nil
+	case "wednesday":
+		return time.Wednesday, nil
+	case "thursday":
+		return time.Thursday, nil
+	case "friday":
+		return time.Friday, nil
+	case "saturday":
+		return time.Saturday, nil
+	case "sunday":
+		return time.Sunday, nil
+	default:
+		return time.Monday, fmt.Errorf("invalid week start '%s': expected iso or a weekday name", s)
+	}
+}
@@ -11,14 +11,28 @@ type Parser interface {
 }
 
 // ISO8601Parser implements the Parser interface for ISO-8601 dates
-type ISO8601Parser struct{}
+// and relative expressions such as "today" or "now-36h". Inputs without
+// an explicit offset are interpreted in the parser's location.
+type ISO8601Parser struct {
+	loc *time.Location
+	now func() time.Time
+}
 
-// NewISO8601Parser creates a new ISO-8601 date parser
+// NewISO8601Parser creates a new ISO-8601 date parser that interprets inputs in UTC
 func NewISO8601Parser() *ISO8601Parser {
-	return &ISO8601Parser{}
+	return NewISO8601ParserInLocation(time.UTC)
 }
 
-// ParseToEpoch converts an ISO-8601 date string to Unix epoch
+// NewISO8601ParserInLocation creates a parser that interprets date-only, naive
+// datetime and relative inputs in loc
+func NewISO8601ParserInLocation(loc *time.Location) *ISO8601Parser {
+	return &ISO8601Parser{
+		loc: loc,
+		now: time.Now,
+	}
+}
+
+// ParseToEpoch converts an ISO-8601 date string or relative expression to Unix epoch
 func (p *ISO8601Parser) ParseToEpoch(dateStr string) (int64, error) {
 	// Try parsing full ISO-8601 datetime first
 	t, err := time.Parse(time.RFC3339, dateStr)
@@ -26,22 +40,39 @@ func (p *ISO8601Parser) ParseToEpoch(dateStr string) (int64, error) {
 		return t.Unix(), nil
 	}
 	
-	// Try parsing date-only format (YYYY-MM-DD)
-	t, err = time.Parse("2006-01-02", dateStr)
+	// Try parsing date-only format (YYYY-MM-DD) as midnight in the parser's location
+	t, err = time.ParseInLocation("2006-01-02", dateStr, p.loc)
 	if err == nil {
 		return t.Unix(), nil
 	}
 	
+	// Try parsing naive datetimes without an offset (YYYY-MM-DDTHH:MM[:SS])
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02T15:04"} {
+		t, err = time.ParseInLocation(layout, dateStr, p.loc)
+		if err == nil {
+			return t.Unix(), nil
+		}
+	}
+	
 	// Try parsing date with timezone (YYYY-MM-DD+TZ)
 	t, err = time.Parse("2006-01-02Z07:00", dateStr)
 	if err == nil {
 		return t.Unix(), nil
 	}
 	
-	return 0, fmt.Errorf("invalid date format: expected ISO-8601 datetime (2006-01-02T15:04:05Z) or date (2006-01-02)")
+	// Try relative expressions (today, -7d, now-36h, ...)
+	if IsRelative(dateStr) {
+		t, err = ParseRelative(dateStr, p.now().In(p.loc))
+		if err != nil {
+			return 0, err
+		}
+		return t.Unix(), nil
+	}
+	
+	return 0, fmt.Errorf("invalid date format: expected ISO-8601 datetime (2006-01-02T15:04:05Z), date (2006-01-02) or relative expression (today, -7d, now-36h)")
 }
 
 // ConvertEpochToLocal converts Unix epoch to local time in the specified timezone
 func ConvertEpochToLocal(epoch int64, loc *time.Location) time.Time {
 	return time.Unix(epoch, 0).In(loc)
-}
\ No newline at end of file
+}
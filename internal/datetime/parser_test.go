@@ -2,6 +2,7 @@ package datetime
 
 import (
 	"testing"
+	"time"
 )
 
 func TestISO8601Parser_ParseToEpoch(t *testing.T) {
@@ -60,4 +61,49 @@ func TestISO8601Parser_ParseToEpoch(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestISO8601Parser_ParseToEpochInLocation(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Sao_Paulo")
+	parser := NewISO8601ParserInLocation(loc)
+	
+	tests := []struct {
+		name     string
+		input    string
+		expected int64
+	}{
+		{
+			name:     "Date only is midnight in location",
+			input:    "2025-06-20",
+			expected: 1750388400, // 2025-06-20T03:00:00Z
+		},
+		{
+			name:     "Naive datetime is interpreted in location",
+			input:    "2025-06-20T10:30:00",
+			expected: 1750426200, // 2025-06-20T13:30:00Z
+		},
+		{
+			name:     "Naive datetime without seconds",
+			input:    "2025-06-20T10:30",
+			expected: 1750426200,
+		},
+		{
+			name:     "Explicit offset wins over location",
+			input:    "2025-06-20T00:00:00Z",
+			expected: 1750377600,
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parser.ParseToEpoch(tt.input)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			
+			if result != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, result)
+			}
+		})
+	}
+}
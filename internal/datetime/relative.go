@@ -0,0 +1,144 @@
+package datetime
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// relativePattern matches an optional anchor followed by zero or more signed offsets,
+// e.g. "today", "-7d", "now-36h", "yesterday+12h"
+var relativePattern = regexp.MustCompile(`^(now|today|yesterday|tomorrow)?((?:[+-]\d+(?:mo|m|h|d|w|y))*)$`)
+
+// offsetPattern matches a single signed offset inside a relative expression
+var offsetPattern = regexp.MustCompile(`([+-])(\d+)(mo|m|h|d|w|y)`)
+
+// RangePresets lists the named ranges accepted by ResolveRange
+var RangePresets = []string{
+	"today",
+	"yesterday",
+	"last-7-days",
+	"last-30-days",
+	"this-month",
+	"last-month",
+	"month-to-date",
+	"last-quarter",
+}
+
+// IsRelative reports whether s looks like a relative date expression
+func IsRelative(s string) bool {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return s != "" && relativePattern.MatchString(s)
+}
+
+// ParseRelative resolves a relative expression against now, in now's location.
+//
+// Anchors are "now", "today", "yesterday" and "tomorrow" (the last three at midnight).
+// Offsets use the units m (minutes), h (hours), d (days), w (weeks), mo (months) and y (years).
+// Without an anchor, day-based offsets count from today's midnight and minute or hour
+// offsets count from now, so "-7d" is midnight seven days ago and "-36h" is 36 hours ago.
+func ParseRelative(s string, now time.Time) (time.Time, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	match := relativePattern.FindStringSubmatch(s)
+	if s == "" || match == nil {
+		return time.Time{}, fmt.Errorf("invalid relative date '%s': expected e.g. today, yesterday, -7d, -2w or now-36h", s)
+	}
+
+	anchor := match[1]
+	offsets := offsetPattern.FindAllStringSubmatch(match[2], -1)
+
+	if anchor == "" {
+		anchor = "today"
+		if len(offsets) > 0 && (offsets[0][3] == "m" || offsets[0][3] == "h") {
+			anchor = "now"
+		}
+	}
+
+	t := StartOfDay(now)
+	switch anchor {
+	case "now":
+		t = now
+	case "yesterday":
+		t = t.AddDate(0, 0, -1)
+	case "tomorrow":
+		t = t.AddDate(0, 0, 1)
+	}
+
+	for _, offset := range offsets {
+		n, err := strconv.Atoi(offset[2])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid offset '%s' in relative date '%s'", offset[0], s)
+		}
+		if offset[1] == "-" {
+			n = -n
+		}
+
+		switch offset[3] {
+		case "m":
+			t = t.Add(time.Duration(n) * time.Minute)
+		case "h":
+			t = t.Add(time.Duration(n) * time.Hour)
+		case "d":
+			t = t.AddDate(0, 0, n)
+		case "w":
+			t = t.AddDate(0, 0, 7*n)
+		case "mo":
+			t = t.AddDate(0, n, 0)
+		case "y":
+			t = t.AddDate(n, 0, 0)
+		}
+	}
+
+	return t, nil
+}
+
+// ResolveRange returns the [start, end) window of a named range preset, in now's location
+func ResolveRange(name string, now time.Time) (time.Time, time.Time, error) {
+	today := StartOfDay(now)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	quarterStart := StartOfQuarter(now)
+
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "today":
+		return today, today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), today, nil
+	case "last-7-days":
+		return today.AddDate(0, 0, -7), today, nil
+	case "last-30-days":
+		return today.AddDate(0, 0, -30), today, nil
+	case "this-month":
+		return monthStart, monthStart.AddDate(0, 1, 0), nil
+	case "last-month":
+		return monthStart.AddDate(0, -1, 0), monthStart, nil
+	case "month-to-date":
+		return monthStart, now, nil
+	case "last-quarter":
+		return quarterStart.AddDate(0, -3, 0), quarterStart, nil
+	default:
+		return time.Time{}, time.Time{}, fmt.Errorf("unknown range '%s': expected one of %s", name, strings.Join(RangePresets, ", "))
+	}
+}
+
+// IsValidRange reports whether name is a known range preset
+func IsValidRange(name string) bool {
+	for _, preset := range RangePresets {
+		if strings.EqualFold(strings.TrimSpace(name), preset) {
+			return true
+		}
+	}
+	return false
+}
+
+// StartOfDay returns midnight of t's day in t's location
+func StartOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// StartOfQuarter returns midnight of the first day of t's calendar quarter in t's location
+func StartOfQuarter(t time.Time) time.Time {
+	month := time.Month((int(t.Month())-1)/3*3 + 1)
+	return time.Date(t.Year(), month, 1, 0, 0, 0, 0, t.Location())
+}
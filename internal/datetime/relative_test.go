@@ -0,0 +1,104 @@
+package datetime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRelative(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Sao_Paulo")
+	now := time.Date(2025, 6, 24, 15, 30, 0, 0, loc)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected time.Time
+		hasError bool
+	}{
+		{"Today", "today", time.Date(2025, 6, 24, 0, 0, 0, 0, loc), false},
+		{"Yesterday", "yesterday", time.Date(2025, 6, 23, 0, 0, 0, 0, loc), false},
+		{"Now", "now", now, false},
+		{"Days ago", "-7d", time.Date(2025, 6, 17, 0, 0, 0, 0, loc), false},
+		{"Weeks ago", "-2w", time.Date(2025, 6, 10, 0, 0, 0, 0, loc), false},
+		{"Hours ago without anchor", "-36h", now.Add(-36 * time.Hour), false},
+		{"Now minus hours", "now-36h", now.Add(-36 * time.Hour), false},
+		{"Months ago", "today-1mo", time.Date(2025, 5, 24, 0, 0, 0, 0, loc), false},
+		{"Chained offsets", "yesterday+12h", time.Date(2025, 6, 23, 12, 0, 0, 0, loc), false},
+		{"Uppercase", "TODAY", time.Date(2025, 6, 24, 0, 0, 0, 0, loc), false},
+		{"Unknown anchor", "lastweek", time.Time{}, true},
+		{"Unknown unit", "-7x", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseRelative(tt.input, now)
+
+			if tt.hasError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !result.Equal(tt.expected) {
+				t.Errorf("Expected %s, got %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestResolveRange(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Sao_Paulo")
+	now := time.Date(2025, 5, 14, 9, 0, 0, 0, loc)
+
+	tests := []struct {
+		name          string
+		expectedStart time.Time
+		expectedEnd   time.Time
+	}{
+		{"last-7-days", time.Date(2025, 5, 7, 0, 0, 0, 0, loc), time.Date(2025, 5, 14, 0, 0, 0, 0, loc)},
+		{"this-month", time.Date(2025, 5, 1, 0, 0, 0, 0, loc), time.Date(2025, 6, 1, 0, 0, 0, 0, loc)},
+		{"last-month", time.Date(2025, 4, 1, 0, 0, 0, 0, loc), time.Date(2025, 5, 1, 0, 0, 0, 0, loc)},
+		{"month-to-date", time.Date(2025, 5, 1, 0, 0, 0, 0, loc), now},
+		{"last-quarter", time.Date(2025, 1, 1, 0, 0, 0, 0, loc), time.Date(2025, 4, 1, 0, 0, 0, 0, loc)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := ResolveRange(tt.name, now)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !start.Equal(tt.expectedStart) || !end.Equal(tt.expectedEnd) {
+				t.Errorf("Expected %s - %s, got %s - %s", tt.expectedStart, tt.expectedEnd, start, end)
+			}
+		})
+	}
+
+	if _, _, err := ResolveRange("last-fortnight", now); err == nil {
+		t.Errorf("Expected error for unknown range")
+	}
+}
+
+func TestISO8601Parser_ParseRelativeInLocation(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Sao_Paulo")
+	parser := NewISO8601ParserInLocation(loc)
+	parser.now = func() time.Time {
+		return time.Date(2025, 6, 24, 12, 0, 0, 0, time.UTC)
+	}
+
+	// Midnight in Sao Paulo is 03:00 UTC
+	result, err := parser.ParseToEpoch("today")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if result != 1750734000 {
+		t.Errorf("Expected 1750734000, got %d", result)
+	}
+}
@@ -0,0 +1,103 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+
+	"wppanalyticscli/internal/models"
+)
+
+// AnalyticsRow is one WABA analytics bucket in the Parquet schema
+type AnalyticsRow struct {
+	WBAID       string    `parquet:"wba_id"`
+	Granularity string    `parquet:"granularity"`
+	Start       time.Time `parquet:"start,timestamp(millisecond:utc)"`
+	End         time.Time `parquet:"end,timestamp(millisecond:utc)"`
+	Sent        int64     `parquet:"sent"`
+	Delivered   int64     `parquet:"delivered"`
+}
+
+// TemplateRow is one template analytics bucket in the Parquet schema, with its
+// clicks and costs as repeated groups
+type TemplateRow struct {
+	WBAID       string     `parquet:"wba_id"`
+	TemplateID  string     `parquet:"template_id"`
+	Granularity string     `parquet:"granularity"`
+	Start       time.Time  `parquet:"start,timestamp(millisecond:utc)"`
+	End         time.Time  `parquet:"end,timestamp(millisecond:utc)"`
+	Sent        int64      `parquet:"sent"`
+	Delivered   int64      `parquet:"delivered"`
+	Read        int64      `parquet:"read"`
+	Clicks      []ClickRow `parquet:"clicks,list"`
+	Costs       []CostRow  `parquet:"costs,list"`
+	AmountSpent *float64   `parquet:"amount_spent,optional"`
+}
+
+// ClickRow is one clicked button of a template bucket
+type ClickRow struct {
+	Type          string `parquet:"type"`
+	ButtonContent string `parquet:"button_content"`
+	Count         int64  `parquet:"count"`
+}
+
+// CostRow is one cost metric of a template bucket
+type CostRow struct {
+	Type  string  `parquet:"type"`
+	Value float64 `parquet:"value"`
+}
+
+// WriteAnalyticsParquet writes the analytics buckets of response to w as Parquet
+func WriteAnalyticsParquet(w io.Writer, response *models.AnalyticsResponse) (int, error) {
+	rows := make([]AnalyticsRow, 0, len(response.Analytics.DataPoints))
+	for _, dp := range response.Analytics.DataPoints {
+		rows = append(rows, AnalyticsRow{
+			WBAID:       response.ID,
+			Granularity: response.Analytics.Granularity,
+			Start:       time.Unix(dp.Start, 0).UTC(),
+			End:         time.Unix(dp.End, 0).UTC(),
+			Sent:        int64(dp.Sent),
+			Delivered:   int64(dp.Delivered),
+		})
+	}
+	if err := parquet.Write(w, rows); err != nil {
+		return 0, fmt.Errorf("failed to write parquet: %w", err)
+	}
+	return len(rows), nil
+}
+
+// WriteTemplateParquet writes the template buckets of response to w as Parquet
+func WriteTemplateParquet(w io.Writer, wbaID string, response *models.TemplateAnalyticsResponse) (int, error) {
+	var rows []TemplateRow
+	for _, data := range response.Data {
+		for _, dp := range data.DataPoints {
+			row := TemplateRow{
+				WBAID:       wbaID,
+				TemplateID:  dp.TemplateID,
+				Granularity: data.Granularity,
+				Start:       time.Unix(dp.Start, 0).UTC(),
+				End:         time.Unix(dp.End, 0).UTC(),
+				Sent:        int64(dp.Sent),
+				Delivered:   int64(dp.Delivered),
+				Read:        int64(dp.Read),
+			}
+			for _, click := range dp.Clicked {
+				row.Clicks = append(row.Clicks, ClickRow{Type: click.Type, ButtonContent: click.ButtonContent, Count: int64(click.Count)})
+			}
+			for _, cost := range dp.Cost {
+				row.Costs = append(row.Costs, CostRow{Type: cost.Type, Value: cost.Value})
+				if cost.Type == "amount_spent" {
+					value := cost.Value
+					row.AmountSpent = &value
+				}
+			}
+			rows = append(rows, row)
+		}
+	}
+	if err := parquet.Write(w, rows); err != nil {
+		return 0, fmt.Errorf("failed to write parquet: %w", err)
+	}
+	return len(rows), nil
+}
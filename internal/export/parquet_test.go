@@ -0,0 +1,107 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+
+	"wppanalyticscli/internal/models"
+)
+
+func TestWriteAnalyticsParquet(t *testing.T) {
+	response := &models.AnalyticsResponse{ID: "123"}
+	response.Analytics.Granularity = "DAY"
+	response.Analytics.DataPoints = []models.DataPoint{
+		{Start: 1750388400, End: 1750474800, Sent: 1523, Delivered: 1490},
+		{Start: 1750474800, End: 1750561200, Sent: 10, Delivered: 9},
+	}
+
+	var buf bytes.Buffer
+	n, err := WriteAnalyticsParquet(&buf, response)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("Expected 2 rows written, got %d", n)
+	}
+
+	rows, err := parquet.Read[AnalyticsRow](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("Unexpected error reading parquet: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(rows))
+	}
+	if !rows[0].Start.Equal(time.Unix(1750388400, 0)) || rows[0].Start.Location() != time.UTC {
+		t.Errorf("Expected UTC start 2025-06-20T03:00:00Z, got %v", rows[0].Start)
+	}
+	if rows[0].Sent != 1523 || rows[0].Delivered != 1490 || rows[0].WBAID != "123" || rows[0].Granularity != "DAY" {
+		t.Errorf("Unexpected first row %+v", rows[0])
+	}
+}
+
+func TestWriteTemplateParquet_Schema(t *testing.T) {
+	response := &models.TemplateAnalyticsResponse{
+		Data: []models.TemplateAnalyticsData{{
+			Granularity: "DAILY",
+			DataPoints: []models.TemplateDataPoint{
+				{
+					TemplateID: "1",
+					Start:      1750388400,
+					End:        1750474800,
+					Sent:       100,
+					Delivered:  95,
+					Read:       60,
+					Clicked: []models.ClickedAction{
+						{Type: "quick_reply_button", ButtonContent: "Yes", Count: 7},
+						{Type: "url_button", ButtonContent: "Shop", Count: 3},
+					},
+					Cost: []models.CostMetric{{Type: "amount_spent", Value: 3.1}, {Type: "cost_per_delivered", Value: 0.0326}},
+				},
+				{TemplateID: "2", Start: 1750388400, End: 1750474800, Sent: 5},
+			},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if _, err := WriteTemplateParquet(&buf, "123", response); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	file, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("Unexpected error opening parquet: %v", err)
+	}
+	schema := file.Schema().String()
+	for _, want := range []string{
+		"required int64 start (TIMESTAMP(isAdjustedToUTC=true,unit=MILLIS))",
+		"required int64 sent (INT(64,true))",
+		"optional double amount_spent",
+		"repeated group list",
+		"required binary button_content (STRING)",
+	} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("Expected schema to contain %q, got:\n%s", want, schema)
+		}
+	}
+
+	rows, err := parquet.Read[TemplateRow](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("Unexpected error reading parquet: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(rows))
+	}
+	if len(rows[0].Clicks) != 2 || rows[0].Clicks[1].ButtonContent != "Shop" || rows[0].Clicks[1].Count != 3 {
+		t.Errorf("Expected both clicked buttons, got %+v", rows[0].Clicks)
+	}
+	if rows[0].AmountSpent == nil || *rows[0].AmountSpent != 3.1 || len(rows[0].Costs) != 2 {
+		t.Errorf("Expected amount spent 3.1 and 2 costs, got %v and %+v", rows[0].AmountSpent, rows[0].Costs)
+	}
+	if rows[1].AmountSpent != nil || len(rows[1].Clicks) != 0 {
+		t.Errorf("Expected a template without cost or clicks, got %+v", rows[1])
+	}
+}
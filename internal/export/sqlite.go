@@ -0,0 +1,291 @@
+package export
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"wppanalyticscli/internal/models"
+)
+
+// schemaVersion is stored in PRAGMA user_version so later releases can migrate
+const schemaVersion = 1
+
+// schema creates the normalized tables. Rows are keyed by their natural keys so
+// exporting an overlapping window again updates rows instead of duplicating them.
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS analytics (
+		wba_id      TEXT    NOT NULL,
+		start_time  INTEGER NOT NULL,
+		end_time    INTEGER NOT NULL,
+		day         TEXT    NOT NULL,
+		sent        INTEGER NOT NULL,
+		delivered   INTEGER NOT NULL,
+		exported_at INTEGER NOT NULL,
+		PRIMARY KEY (wba_id, start_time)
+	)`,
+	`CREATE INDEX IF NOT EXISTS analytics_day ON analytics (day)`,
+	`CREATE TABLE IF NOT EXISTS templates (
+		template_id       TEXT PRIMARY KEY,
+		wba_id            TEXT    NOT NULL,
+		name              TEXT    NOT NULL,
+		language          TEXT    NOT NULL,
+		status            TEXT    NOT NULL,
+		category          TEXT    NOT NULL,
+		previous_category TEXT    NOT NULL,
+		rejected_reason   TEXT    NOT NULL,
+		quality_score     TEXT    NOT NULL,
+		exported_at       INTEGER NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS templates_wba_name ON templates (wba_id, name)`,
+	`CREATE TABLE IF NOT EXISTS template_buttons (
+		template_id  TEXT    NOT NULL,
+		position     INTEGER NOT NULL,
+		type         TEXT    NOT NULL,
+		text         TEXT    NOT NULL,
+		url          TEXT    NOT NULL,
+		phone_number TEXT    NOT NULL,
+		PRIMARY KEY (template_id, position)
+	)`,
+	`CREATE TABLE IF NOT EXISTS template_analytics (
+		template_id TEXT    NOT NULL,
+		start_time  INTEGER NOT NULL,
+		wba_id      TEXT    NOT NULL,
+		end_time    INTEGER NOT NULL,
+		day         TEXT    NOT NULL,
+		sent        INTEGER NOT NULL,
+		delivered   INTEGER NOT NULL,
+		read        INTEGER NOT NULL,
+		exported_at INTEGER NOT NULL,
+		PRIMARY KEY (template_id, start_time)
+	)`,
+	`CREATE INDEX IF NOT EXISTS template_analytics_wba_day ON template_analytics (wba_id, day)`,
+	`CREATE TABLE IF NOT EXISTS template_clicks (
+		template_id    TEXT    NOT NULL,
+		start_time     INTEGER NOT NULL,
+		type           TEXT    NOT NULL,
+		button_content TEXT    NOT NULL,
+		count          INTEGER NOT NULL,
+		PRIMARY KEY (template_id, start_time, type, button_content)
+	)`,
+	`CREATE INDEX IF NOT EXISTS template_clicks_button ON template_clicks (button_content)`,
+	`CREATE TABLE IF NOT EXISTS template_costs (
+		template_id TEXT    NOT NULL,
+		start_time  INTEGER NOT NULL,
+		type        TEXT    NOT NULL,
+		value       REAL    NOT NULL,
+		PRIMARY KEY (template_id, start_time, type)
+	)`,
+}
+
+// SQLiteWriter writes analytics and template data into a SQLite database
+type SQLiteWriter struct {
+	db  *sql.DB
+	loc *time.Location
+	now func() time.Time
+}
+
+// OpenSQLite opens or creates the database at path and its tables. Days are
+// written as YYYY-MM-DD in loc, next to the raw epochs.
+func OpenSQLite(path string, loc *time.Location) (*SQLiteWriter, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	// A single connection keeps transactions and pragmas on the same database handle
+	db.SetMaxOpenConns(1)
+
+	var version int
+	if err := db.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if version > schemaVersion {
+		db.Close()
+		return nil, fmt.Errorf("%s was written by a newer version (schema %d, expected %d)", path, version, schemaVersion)
+	}
+
+	for _, statement := range schema {
+		if _, err := db.Exec(statement); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to create tables in %s: %w", path, err)
+		}
+	}
+	if _, err := db.Exec(fmt.Sprintf(`PRAGMA user_version = %d`, schemaVersion)); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to set schema version: %w", err)
+	}
+
+	if loc == nil {
+		loc = time.UTC
+	}
+	return &SQLiteWriter{db: db, loc: loc, now: time.Now}, nil
+}
+
+// Close closes the database
+func (w *SQLiteWriter) Close() error {
+	return w.db.Close()
+}
+
+// WriteAnalytics upserts WABA analytics points and returns how many were written
+func (w *SQLiteWriter) WriteAnalytics(wbaID string, points []models.DataPoint) (int, error) {
+	exportedAt := w.now().Unix()
+	err := w.inTx(func(tx *sql.Tx) error {
+		stmt, err := tx.Prepare(`INSERT INTO analytics (wba_id, start_time, end_time, day, sent, delivered, exported_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (wba_id, start_time) DO UPDATE SET
+				end_time = excluded.end_time, day = excluded.day, sent = excluded.sent,
+				delivered = excluded.delivered, exported_at = excluded.exported_at`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for _, dp := range points {
+			if _, err := stmt.Exec(wbaID, dp.Start, dp.End, w.day(dp.Start), dp.Sent, dp.Delivered, exportedAt); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to write analytics: %w", err)
+	}
+	return len(points), nil
+}
+
+// WriteTemplatePoints upserts template analytics points and replaces their
+// clicks and costs, returning how many points were written
+func (w *SQLiteWriter) WriteTemplatePoints(wbaID string, points []models.TemplateDataPoint) (int, error) {
+	exportedAt := w.now().Unix()
+	err := w.inTx(func(tx *sql.Tx) error {
+		upsert, err := tx.Prepare(`INSERT INTO template_analytics (template_id, start_time, wba_id, end_time, day, sent, delivered, read, exported_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (template_id, start_time) DO UPDATE SET
+				wba_id = excluded.wba_id, end_time = excluded.end_time, day = excluded.day, sent = excluded.sent,
+				delivered = excluded.delivered, read = excluded.read, exported_at = excluded.exported_at`)
+		if err != nil {
+			return err
+		}
+		defer upsert.Close()
+
+		insertClick, err := tx.Prepare(`INSERT INTO template_clicks (template_id, start_time, type, button_content, count)
+			VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT (template_id, start_time, type, button_content) DO UPDATE SET count = count + excluded.count`)
+		if err != nil {
+			return err
+		}
+		defer insertClick.Close()
+
+		insertCost, err := tx.Prepare(`INSERT INTO template_costs (template_id, start_time, type, value)
+			VALUES (?, ?, ?, ?)
+			ON CONFLICT (template_id, start_time, type) DO UPDATE SET value = excluded.value`)
+		if err != nil {
+			return err
+		}
+		defer insertCost.Close()
+
+		for _, dp := range points {
+			if _, err := upsert.Exec(dp.TemplateID, dp.Start, wbaID, dp.End, w.day(dp.Start), dp.Sent, dp.Delivered, dp.Read, exportedAt); err != nil {
+				return err
+			}
+
+			// Clicks and costs of a day are replaced as a whole, so buttons that
+			// no longer appear in the API response do not linger
+			for _, table := range []string{"template_clicks", "template_costs"} {
+				if _, err := tx.Exec(`DELETE FROM `+table+` WHERE template_id = ? AND start_time = ?`, dp.TemplateID, dp.Start); err != nil {
+					return err
+				}
+			}
+			for _, click := range dp.Clicked {
+				if _, err := insertClick.Exec(dp.TemplateID, dp.Start, click.Type, click.ButtonContent, click.Count); err != nil {
+					return err
+				}
+			}
+			for _, cost := range dp.Cost {
+				if _, err := insertCost.Exec(dp.TemplateID, dp.Start, cost.Type, cost.Value); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to write template analytics: %w", err)
+	}
+	return len(points), nil
+}
+
+// WriteTemplates upserts template metadata and replaces each template's buttons
+func (w *SQLiteWriter) WriteTemplates(wbaID string, templates []models.MessageTemplate) (int, error) {
+	exportedAt := w.now().Unix()
+	err := w.inTx(func(tx *sql.Tx) error {
+		upsert, err := tx.Prepare(`INSERT INTO templates (template_id, wba_id, name, language, status, category, previous_category, rejected_reason, quality_score, exported_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (template_id) DO UPDATE SET
+				wba_id = excluded.wba_id, name = excluded.name, language = excluded.language, status = excluded.status,
+				category = excluded.category, previous_category = excluded.previous_category,
+				rejected_reason = excluded.rejected_reason, quality_score = excluded.quality_score,
+				exported_at = excluded.exported_at`)
+		if err != nil {
+			return err
+		}
+		defer upsert.Close()
+
+		insertButton, err := tx.Prepare(`INSERT INTO template_buttons (template_id, position, type, text, url, phone_number)
+			VALUES (?, ?, ?, ?, ?, ?)`)
+		if err != nil {
+			return err
+		}
+		defer insertButton.Close()
+
+		for _, template := range templates {
+			qualityScore := ""
+			if template.QualityScore != nil {
+				qualityScore = template.QualityScore.Score
+			}
+			if _, err := upsert.Exec(template.ID, wbaID, template.Name, template.Language, template.Status, template.Category,
+				template.PreviousCategory, template.RejectedReason, qualityScore, exportedAt); err != nil {
+				return err
+			}
+
+			if _, err := tx.Exec(`DELETE FROM template_buttons WHERE template_id = ?`, template.ID); err != nil {
+				return err
+			}
+			position := 0
+			for _, component := range template.Components {
+				for _, button := range component.Buttons {
+					if _, err := insertButton.Exec(template.ID, position, button.Type, button.Text, button.URL, button.PhoneNumber); err != nil {
+						return err
+					}
+					position++
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to write templates: %w", err)
+	}
+	return len(templates), nil
+}
+
+// inTx runs fn in a transaction, committing only when it succeeds
+func (w *SQLiteWriter) inTx(fn func(tx *sql.Tx) error) error {
+	tx, err := w.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// day formats the start of a bucket as a date in the export timezone
+func (w *SQLiteWriter) day(epoch int64) string {
+	return time.Unix(epoch, 0).In(w.loc).Format("2006-01-02")
+}
@@ -0,0 +1,154 @@
+package export
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"wppanalyticscli/internal/models"
+)
+
+func openTestWriter(t *testing.T) (*SQLiteWriter, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "export.db")
+	writer, err := OpenSQLite(path, time.UTC)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	t.Cleanup(func() { writer.Close() })
+	return writer, path
+}
+
+func countRows(t *testing.T, writer *SQLiteWriter, table string) int {
+	t.Helper()
+	var n int
+	if err := writer.db.QueryRow(`SELECT COUNT(*) FROM ` + table).Scan(&n); err != nil {
+		t.Fatalf("Unexpected error counting %s: %v", table, err)
+	}
+	return n
+}
+
+func TestWriteAnalytics_UpsertsOverlappingWindows(t *testing.T) {
+	writer, _ := openTestWriter(t)
+
+	first := []models.DataPoint{
+		{Start: 1750291200, End: 1750377600, Sent: 10, Delivered: 9},
+		{Start: 1750377600, End: 1750464000, Sent: 20, Delivered: 18},
+	}
+	second := []models.DataPoint{
+		{Start: 1750377600, End: 1750464000, Sent: 25, Delivered: 24},
+		{Start: 1750464000, End: 1750550400, Sent: 30, Delivered: 29},
+	}
+	for _, points := range [][]models.DataPoint{first, second} {
+		if _, err := writer.WriteAnalytics("123", points); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if n := countRows(t, writer, "analytics"); n != 3 {
+		t.Errorf("Expected 3 analytics rows, got %d", n)
+	}
+
+	var sent int
+	var day string
+	if err := writer.db.QueryRow(`SELECT sent, day FROM analytics WHERE wba_id = ? AND start_time = ?`, "123", 1750377600).Scan(&sent, &day); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if sent != 25 || day != "2025-06-20" {
+		t.Errorf("Expected the latest export of 2025-06-20, got sent=%d day=%s", sent, day)
+	}
+}
+
+func TestWriteTemplatePoints_ReplacesClicksAndCosts(t *testing.T) {
+	writer, _ := openTestWriter(t)
+
+	point := models.TemplateDataPoint{
+		TemplateID: "1",
+		Start:      1750291200,
+		End:        1750377600,
+		Sent:       100,
+		Delivered:  95,
+		Read:       60,
+		Clicked: []models.ClickedAction{
+			{Type: "quick_reply_button", ButtonContent: "Yes", Count: 7},
+			{Type: "quick_reply_button", ButtonContent: "No", Count: 2},
+		},
+		Cost: []models.CostMetric{{Type: "amount_spent", Value: 1.5}, {Type: "cost_per_delivered", Value: 0.0158}},
+	}
+	if _, err := writer.WriteTemplatePoints("123", []models.TemplateDataPoint{point}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	point.Read = 70
+	point.Clicked = []models.ClickedAction{{Type: "quick_reply_button", ButtonContent: "Yes", Count: 9}}
+	if _, err := writer.WriteTemplatePoints("123", []models.TemplateDataPoint{point}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if n := countRows(t, writer, "template_analytics"); n != 1 {
+		t.Errorf("Expected 1 template analytics row, got %d", n)
+	}
+	if n := countRows(t, writer, "template_clicks"); n != 1 {
+		t.Errorf("Expected the stale button to be removed, got %d click rows", n)
+	}
+	if n := countRows(t, writer, "template_costs"); n != 2 {
+		t.Errorf("Expected 2 cost rows, got %d", n)
+	}
+
+	var read, clicks int
+	if err := writer.db.QueryRow(`SELECT a.read, c.count FROM template_analytics a
+		JOIN template_clicks c ON c.template_id = a.template_id AND c.start_time = a.start_time`).Scan(&read, &clicks); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if read != 70 || clicks != 9 {
+		t.Errorf("Expected read=70 clicks=9, got read=%d clicks=%d", read, clicks)
+	}
+}
+
+func TestWriteTemplates_UpsertsMetadataAndButtons(t *testing.T) {
+	writer, path := openTestWriter(t)
+
+	template := models.MessageTemplate{
+		ID:           "1",
+		Name:         "welcome",
+		Language:     "pt_BR",
+		Status:       "APPROVED",
+		Category:     "MARKETING",
+		QualityScore: &models.QualityScore{Score: "GREEN"},
+		Components: []models.TemplateComponent{
+			{Type: "BODY", Text: "Hi"},
+			{Type: "BUTTONS", Buttons: []models.TemplateButton{{Type: "QUICK_REPLY", Text: "Yes"}, {Type: "URL", Text: "Shop", URL: "https://example.com"}}},
+		},
+	}
+	if _, err := writer.WriteTemplates("123", []models.MessageTemplate{template}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	template.Status = "PAUSED"
+	template.Components = template.Components[:1]
+	if _, err := writer.WriteTemplates("123", []models.MessageTemplate{template}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var status, quality string
+	if err := writer.db.QueryRow(`SELECT status, quality_score FROM templates WHERE template_id = ?`, "1").Scan(&status, &quality); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if status != "PAUSED" || quality != "GREEN" {
+		t.Errorf("Expected PAUSED with GREEN quality, got %s and %s", status, quality)
+	}
+	if n := countRows(t, writer, "template_buttons"); n != 0 {
+		t.Errorf("Expected buttons removed with their component, got %d", n)
+	}
+
+	// Reopening an existing database keeps its rows
+	writer.Close()
+	reopened, err := OpenSQLite(path, time.UTC)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer reopened.Close()
+	if n := countRows(t, reopened, "templates"); n != 1 {
+		t.Errorf("Expected 1 template after reopening, got %d", n)
+	}
+}
@@ -0,0 +1,177 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"wppanalyticscli/internal/models"
+)
+
+// AccountResult is the outcome of one WABA in a multi-account report: its
+// analytics or template analytics, depending on the mode, or the error.
+type AccountResult struct {
+	WBAID     string
+	Analytics *models.AnalyticsResponse
+	Templates *models.TemplateAnalyticsResponse
+	Currency  string // billing currency of the template costs
+	Err       error
+}
+
+// AccountsFormatter formats the consolidated summary of a multi-account report
+type AccountsFormatter struct {
+	reportOptions
+}
+
+// NewAccountsFormatter creates a new multi-account summary formatter
+func NewAccountsFormatter() *AccountsFormatter {
+	return &AccountsFormatter{}
+}
+
+// Format formats sent and delivered per account plus a total across accounts,
+// and lists the accounts that failed
+func (f *AccountsFormatter) Format(results []AccountResult, loc *time.Location) string {
+	var output strings.Builder
+	f.writeAccountsHeader(&output, results, loc)
+	f.writeAnalyticsAccounts(&output, results)
+	f.writeFailedAccounts(&output, results)
+	return output.String()
+}
+
+// FormatTemplate formats template totals per account plus a total across
+// accounts, and lists the accounts that failed
+func (f *AccountsFormatter) FormatTemplate(results []AccountResult, loc *time.Location) string {
+	var output strings.Builder
+	f.writeAccountsHeader(&output, results, loc)
+	f.writeTemplateAccounts(&output, results)
+	f.writeFailedAccounts(&output, results)
+	return output.String()
+}
+
+// writeAccountsHeader writes the summary title, account counts and window
+func (f *AccountsFormatter) writeAccountsHeader(output *strings.Builder, results []AccountResult, loc *time.Location) {
+	output.WriteString("🏢 Accounts Summary\n")
+	output.WriteString(fmt.Sprintf("📱 Accounts: %d (%d failed)\n", len(results), len(failedAccounts(results))))
+	f.writeWindow(output, loc)
+	output.WriteString(fmt.Sprintf("🌎 Timezone: %s\n\n", loc.String()))
+}
+
+// writeFailedAccounts lists the accounts that could not be fetched and why
+func (f *AccountsFormatter) writeFailedAccounts(output *strings.Builder, results []AccountResult) {
+	failed := failedAccounts(results)
+	if len(failed) == 0 {
+		return
+	}
+	output.WriteString(fmt.Sprintf("\n❌ Failed Accounts (%d):\n", len(failed)))
+	for _, result := range failed {
+		output.WriteString(fmt.Sprintf("   • %s: %v\n", result.WBAID, result.Err))
+	}
+}
+
+// failedAccounts returns the results that carry an error
+func failedAccounts(results []AccountResult) []AccountResult {
+	var failed []AccountResult
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// writeAnalyticsAccounts writes sent and delivered per account and in total
+func (f *AccountsFormatter) writeAnalyticsAccounts(output *strings.Builder, results []AccountResult) {
+	table := newTextTable(
+		tableColumn{header: "Account", width: 17, flex: true, min: 10},
+		tableColumn{header: "Sent", width: 11, align: alignRight},
+		tableColumn{header: "Delivered", width: 11, align: alignRight},
+		tableColumn{header: "Deliv%", width: 7, align: alignRight},
+	)
+
+	var totalSent, totalDelivered int
+	for _, result := range results {
+		if result.Analytics == nil {
+			continue
+		}
+		sent, delivered := 0, 0
+		for _, dp := range result.Analytics.Analytics.DataPoints {
+			sent += dp.Sent
+			delivered += dp.Delivered
+		}
+		f.addAnalyticsAccountRow(table, result.WBAID, sent, delivered)
+		totalSent += sent
+		totalDelivered += delivered
+	}
+	table.addSeparator()
+	f.addAnalyticsAccountRow(table, "Σ Total", totalSent, totalDelivered)
+
+	f.renderTable(output, table)
+}
+
+// addAnalyticsAccountRow adds one analytics row, coloring the delivery rate
+func (f *AccountsFormatter) addAnalyticsAccountRow(table *textTable, label string, sent, delivered int) {
+	deliveryRate := percentage(delivered, sent)
+	table.addRow(label, f.count(sent), f.count(delivered),
+		f.paintRate(f.percent(deliveryRate), deliveryRate, deliveryRateGood, deliveryRateWarn))
+}
+
+// writeTemplateAccounts writes template totals per account and in total. Costs
+// are only summed when every account bills in the same currency.
+func (f *AccountsFormatter) writeTemplateAccounts(output *strings.Builder, results []AccountResult) {
+	table := newTextTable(
+		tableColumn{header: "Account", width: 17, flex: true, min: 10},
+		tableColumn{header: "Sent", width: 9, align: alignRight},
+		tableColumn{header: "Delivered", width: 9, align: alignRight},
+		tableColumn{header: "Read", width: 9, align: alignRight},
+		tableColumn{header: "Read%", width: 7, align: alignRight},
+		tableColumn{header: "Clicked", width: 8, align: alignRight},
+		tableColumn{header: "Click%", width: 7, align: alignRight},
+		tableColumn{header: "Cost", width: 12, align: alignRight},
+	)
+
+	var total templateTotals
+	currencies := make(map[string]bool)
+	for _, result := range results {
+		if result.Templates == nil {
+			continue
+		}
+		totals := sumTemplatePoints(templateDataPoints(result.Templates))
+		currency := LookupCurrency(result.Currency)
+		f.addTemplateAccountRow(table, result.WBAID, totals, currency.FormatIn(f.num(), totals.Cost))
+
+		total.Sent += totals.Sent
+		total.Delivered += totals.Delivered
+		total.Read += totals.Read
+		total.Clicked += totals.Clicked
+		total.Cost += totals.Cost
+		currencies[currency.Code] = true
+	}
+
+	totalCost := "mixed"
+	if len(currencies) <= 1 {
+		code := "USD"
+		for c := range currencies {
+			code = c
+		}
+		totalCost = LookupCurrency(code).FormatIn(f.num(), total.Cost)
+	}
+	table.addSeparator()
+	f.addTemplateAccountRow(table, "Σ Total", total, totalCost)
+
+	f.renderTable(output, table)
+
+	if len(currencies) > 1 {
+		output.WriteString("   ℹ️  Note: Accounts bill in different currencies, so costs are not totaled\n")
+	}
+}
+
+// addTemplateAccountRow adds one template row, coloring the read and click rates
+func (f *AccountsFormatter) addTemplateAccountRow(table *textTable, label string, totals templateTotals, cost string) {
+	readRate := percentage(totals.Read, totals.Delivered)
+	clickRate := percentage(totals.Clicked, totals.Delivered)
+	table.addRow(label, f.count(totals.Sent), f.count(totals.Delivered), f.count(totals.Read),
+		f.paintRate(f.percent(readRate), readRate, readRateGood, readRateWarn),
+		f.count(totals.Clicked),
+		f.paintRate(f.percent(clickRate), clickRate, clickRateGood, clickRateWarn),
+		cost)
+}
@@ -0,0 +1,95 @@
+package formatter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"wppanalyticscli/internal/models"
+)
+
+func accountAnalytics(wbaID string, sent, delivered int) *models.AnalyticsResponse {
+	response := &models.AnalyticsResponse{ID: wbaID}
+	response.Analytics.Granularity = "DAY"
+	response.Analytics.DataPoints = []models.DataPoint{{Start: 1750388400, End: 1750474800, Sent: sent, Delivered: delivered}}
+	return response
+}
+
+func accountTemplates(sent, delivered, read, clicked int, cost float64) *models.TemplateAnalyticsResponse {
+	return &models.TemplateAnalyticsResponse{
+		Data: []models.TemplateAnalyticsData{{
+			Granularity: "DAILY",
+			DataPoints: []models.TemplateDataPoint{{
+				TemplateID: "1",
+				Start:      1750388400,
+				End:        1750474800,
+				Sent:       sent,
+				Delivered:  delivered,
+				Read:       read,
+				Clicked:    []models.ClickedAction{{Type: "quick_reply_button", ButtonContent: "Yes", Count: clicked}},
+				Cost:       []models.CostMetric{{Type: "amount_spent", Value: cost}},
+			}},
+		}},
+	}
+}
+
+func TestAccountsFormatter_Format(t *testing.T) {
+	formatter := NewAccountsFormatter()
+	formatter.SetNumbers(NumberFormat{Thousands: ",", Decimal: "."})
+	results := []AccountResult{
+		{WBAID: "111", Analytics: accountAnalytics("111", 1000, 950)},
+		{WBAID: "222", Err: errors.New("API error: (#100) Invalid parameter")},
+		{WBAID: "333", Analytics: accountAnalytics("333", 500, 400)},
+	}
+
+	result := formatter.Format(results, time.UTC)
+
+	expectedContents := []string{
+		"🏢 Accounts Summary",
+		"📱 Accounts: 3 (1 failed)",
+		"111",
+		"333",
+		"Σ Total",
+		"1,500",
+		"1,350",
+		"90.0%",
+		"❌ Failed Accounts (1):",
+		"• 222: API error: (#100) Invalid parameter",
+	}
+	for _, expected := range expectedContents {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Expected result to contain %q, got:\n%s", expected, result)
+		}
+	}
+	if strings.Index(result, "111") > strings.Index(result, "333") {
+		t.Errorf("Expected accounts in the given order")
+	}
+}
+
+func TestAccountsFormatter_FormatTemplateCurrencies(t *testing.T) {
+	formatter := NewAccountsFormatter()
+	sameCurrency := []AccountResult{
+		{WBAID: "111", Templates: accountTemplates(100, 90, 45, 9, 1.5), Currency: "BRL"},
+		{WBAID: "333", Templates: accountTemplates(100, 90, 45, 9, 2), Currency: "BRL"},
+	}
+
+	result := formatter.FormatTemplate(sameCurrency, time.UTC)
+	for _, expected := range []string{"R$ 1.50", "R$ 3.50", "50.0%", "10.0%"} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Expected result to contain %q, got:\n%s", expected, result)
+		}
+	}
+
+	mixed := append(sameCurrency, AccountResult{WBAID: "444", Templates: accountTemplates(10, 9, 4, 1, 0.5), Currency: "USD"})
+	result = formatter.FormatTemplate(mixed, time.UTC)
+	if !strings.Contains(result, "mixed") || !strings.Contains(result, "costs are not totaled") {
+		t.Errorf("Expected no cost total across currencies, got:\n%s", result)
+	}
+
+	failed := []AccountResult{{WBAID: "111", Err: errors.New("timeout")}}
+	result = formatter.FormatTemplate(failed, time.UTC)
+	if !strings.Contains(result, "Click%") || !strings.Contains(result, "• 111: timeout") {
+		t.Errorf("Expected template columns and the failure, got:\n%s", result)
+	}
+}
@@ -0,0 +1,163 @@
+package formatter
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strings"
+)
+
+// chart is a format-neutral chart; the HTML renderer draws it as inline SVG
+type chart struct {
+	kind        string // "line" or "stacked-bar"
+	labels      []string
+	series      []chartSeries
+	formatValue func(v float64) string
+}
+
+// chartSeries is one named series of values, aligned with the chart labels
+type chartSeries struct {
+	name   string
+	color  string
+	values []float64
+}
+
+// Chart geometry in SVG user units; the SVG scales to the page width
+const (
+	chartWidth      = 800
+	chartHeight     = 300
+	chartMarginLeft = 70
+	chartMarginTop  = 36
+	chartMarginEnd  = 20
+	chartMarginBase = 48
+	chartTicks      = 4
+	chartMaxLabels  = 8
+)
+
+// renderSVGChart draws a chart as a self-contained SVG element
+func renderSVGChart(c chart) string {
+	var svg strings.Builder
+
+	svg.WriteString(fmt.Sprintf("<svg class=\"chart\" viewBox=\"0 0 %d %d\" role=\"img\">\n", chartWidth, chartHeight))
+
+	maxValue := chartMax(c)
+	plotWidth := float64(chartWidth - chartMarginLeft - chartMarginEnd)
+	plotHeight := float64(chartHeight - chartMarginTop - chartMarginBase)
+	y := func(v float64) float64 {
+		return float64(chartMarginTop) + plotHeight - v/maxValue*plotHeight
+	}
+
+	// Horizontal grid lines with value labels
+	for i := 0; i <= chartTicks; i++ {
+		value := maxValue * float64(i) / chartTicks
+		svg.WriteString(fmt.Sprintf("<line x1=\"%d\" y1=\"%.1f\" x2=\"%d\" y2=\"%.1f\" stroke=\"#d0d7de\" stroke-width=\"1\"/>\n",
+			chartMarginLeft, y(value), chartWidth-chartMarginEnd, y(value)))
+		svg.WriteString(fmt.Sprintf("<text x=\"%d\" y=\"%.1f\" font-size=\"11\" text-anchor=\"end\" fill=\"#59636e\">%s</text>\n",
+			chartMarginLeft-6, y(value)+4, html.EscapeString(c.format(value))))
+	}
+
+	slots := len(c.labels)
+	if slots == 0 {
+		slots = 1
+	}
+	slotWidth := plotWidth / float64(slots)
+	x := func(i int) float64 {
+		return float64(chartMarginLeft) + slotWidth*(float64(i)+0.5)
+	}
+
+	// Category labels, thinned out so they do not overlap
+	step := int(math.Ceil(float64(len(c.labels)) / chartMaxLabels))
+	if step < 1 {
+		step = 1
+	}
+	for i, label := range c.labels {
+		if i%step != 0 {
+			continue
+		}
+		svg.WriteString(fmt.Sprintf("<text x=\"%.1f\" y=\"%d\" font-size=\"11\" text-anchor=\"middle\" fill=\"#59636e\">%s</text>\n",
+			x(i), chartHeight-chartMarginBase+18, html.EscapeString(truncateString(label, 14))))
+	}
+
+	switch c.kind {
+	case "stacked-bar":
+		barWidth := slotWidth * 0.6
+		for i, label := range c.labels {
+			base := 0.0
+			for _, series := range c.series {
+				value := series.values[i]
+				if value <= 0 {
+					continue
+				}
+				svg.WriteString(fmt.Sprintf("<rect x=\"%.1f\" y=\"%.1f\" width=\"%.1f\" height=\"%.1f\" fill=\"%s\"><title>%s — %s: %s</title></rect>\n",
+					x(i)-barWidth/2, y(base+value), barWidth, y(base)-y(base+value), series.color,
+					html.EscapeString(label), html.EscapeString(series.name), html.EscapeString(c.format(value))))
+				base += value
+			}
+		}
+	default:
+		for _, series := range c.series {
+			var points []string
+			for i, value := range series.values {
+				points = append(points, fmt.Sprintf("%.1f,%.1f", x(i), y(value)))
+			}
+			svg.WriteString(fmt.Sprintf("<polyline points=\"%s\" fill=\"none\" stroke=\"%s\" stroke-width=\"2\"/>\n", strings.Join(points, " "), series.color))
+			for i, value := range series.values {
+				svg.WriteString(fmt.Sprintf("<circle cx=\"%.1f\" cy=\"%.1f\" r=\"3\" fill=\"%s\"><title>%s — %s: %s</title></circle>\n",
+					x(i), y(value), series.color,
+					html.EscapeString(c.labels[i]), html.EscapeString(series.name), html.EscapeString(c.format(value))))
+			}
+		}
+	}
+
+	// Legend above the plot
+	legendX := chartMarginLeft
+	for _, series := range c.series {
+		svg.WriteString(fmt.Sprintf("<rect x=\"%d\" y=\"10\" width=\"12\" height=\"12\" fill=\"%s\"/>\n", legendX, series.color))
+		svg.WriteString(fmt.Sprintf("<text x=\"%d\" y=\"20\" font-size=\"12\" fill=\"#1f2328\">%s</text>\n", legendX+16, html.EscapeString(series.name)))
+		legendX += 16 + 8*len([]rune(series.name)) + 24
+	}
+
+	svg.WriteString("</svg>\n")
+	return svg.String()
+}
+
+// chartMax returns a rounded maximum for the value axis, stacking series for bar charts
+func chartMax(c chart) float64 {
+	maxValue := 0.0
+	for i := range c.labels {
+		stacked := 0.0
+		for _, series := range c.series {
+			if c.kind == "stacked-bar" {
+				stacked += series.values[i]
+			} else if series.values[i] > maxValue {
+				maxValue = series.values[i]
+			}
+		}
+		if stacked > maxValue {
+			maxValue = stacked
+		}
+	}
+	return niceCeiling(maxValue)
+}
+
+// niceCeiling rounds a value up to 1, 2, 2.5 or 5 times a power of ten
+func niceCeiling(v float64) float64 {
+	if v <= 0 {
+		return 1
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(v)))
+	for _, factor := range []float64{1, 2, 2.5, 5, 10} {
+		if v <= factor*magnitude {
+			return factor * magnitude
+		}
+	}
+	return 10 * magnitude
+}
+
+// format formats an axis or tooltip value, defaulting to a plain number
+func (c chart) format(v float64) string {
+	if c.formatValue != nil {
+		return c.formatValue(v)
+	}
+	return fmt.Sprintf("%g", v)
+}
@@ -0,0 +1,127 @@
+package formatter
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ANSI escape sequences used to color table cells
+const (
+	ansiReset  = "\x1b[0m"
+	ansiRed    = "\x1b[31m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+	ansiDim    = "\x1b[2m"
+)
+
+// Rate thresholds, in percent, for coloring cells green (at or above good),
+// yellow (at or above warn) or red
+const (
+	deliveryRateGood = 95.0
+	deliveryRateWarn = 80.0
+	readRateGood     = 50.0
+	readRateWarn     = 25.0
+	clickRateGood    = 5.0
+	clickRateWarn    = 1.0
+)
+
+// SetColor enables ANSI colors for statuses and rates
+func (o *reportOptions) SetColor(enabled bool) {
+	o.color = enabled
+}
+
+// paint wraps s in an ANSI color when colors are enabled
+func (o *reportOptions) paint(s, color string) string {
+	if !o.color || color == "" || s == "" {
+		return s
+	}
+	return color + s + ansiReset
+}
+
+// paintRate colors s by how rate compares with the good and warn thresholds
+func (o *reportOptions) paintRate(s string, rate, good, warn float64) string {
+	switch {
+	case rate >= good:
+		return o.paint(s, ansiGreen)
+	case rate >= warn:
+		return o.paint(s, ansiYellow)
+	default:
+		return o.paint(s, ansiRed)
+	}
+}
+
+// paintShare colors s by part as a percentage of whole, leaving it plain when whole is zero
+func (o *reportOptions) paintShare(s string, part, whole int, good, warn float64) string {
+	if whole <= 0 {
+		return s
+	}
+	return o.paintRate(s, percentage(part, whole), good, warn)
+}
+
+// statusColor returns the color of a template status
+func statusColor(status string) string {
+	switch strings.ToUpper(status) {
+	case "APPROVED":
+		return ansiGreen
+	case "PENDING", "IN_APPEAL", "PAUSED", "LIMIT_EXCEEDED":
+		return ansiYellow
+	case "REJECTED", "DISABLED", "FLAGGED":
+		return ansiRed
+	case "PENDING_DELETION", "DELETED", "ARCHIVED":
+		return ansiDim
+	default:
+		return ""
+	}
+}
+
+// skipANSI returns the length of the ANSI escape sequence at the start of s, or 0
+func skipANSI(s string) int {
+	if len(s) < 2 || s[0] != 0x1b || s[1] != '[' {
+		return 0
+	}
+	for i := 2; i < len(s); i++ {
+		if s[i] >= 0x40 && s[i] <= 0x7e {
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// Plain removes emoji, and the spacing that follows them, from formatted output
+// so it reads cleanly in logs
+func Plain(s string) string {
+	var plain strings.Builder
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		if !isEmoji(runes[i]) {
+			plain.WriteRune(runes[i])
+			continue
+		}
+		// Drop joiners, variation selectors and up to two spaces after the emoji
+		for i+1 < len(runes) && (runes[i+1] == 0x200D || runes[i+1] == 0xFE0F || isEmoji(runes[i+1])) {
+			i++
+		}
+		for spaces := 0; spaces < 2 && i+1 < len(runes) && runes[i+1] == ' '; spaces++ {
+			i++
+		}
+	}
+	return plain.String()
+}
+
+// isEmoji reports whether r is a pictographic symbol used as an emoji
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF:
+		return true
+	case r >= 0x2600 && r <= 0x27BF:
+		return true
+	case r >= 0x2300 && r <= 0x23FF:
+		return true
+	case r >= 0x2B00 && r <= 0x2BFF:
+		return true
+	case r == 0x2139 || r == 0x203C || r == 0x2049:
+		return true
+	default:
+		return r > 0xFFFF && unicode.Is(unicode.So, r)
+	}
+}
@@ -0,0 +1,116 @@
+package formatter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"wppanalyticscli/internal/models"
+)
+
+func TestPaintRate(t *testing.T) {
+	options := reportOptions{color: true}
+
+	tests := []struct {
+		rate     float64
+		expected string
+	}{
+		{97.5, ansiGreen + "x" + ansiReset},
+		{95, ansiGreen + "x" + ansiReset},
+		{85, ansiYellow + "x" + ansiReset},
+		{40, ansiRed + "x" + ansiReset},
+	}
+
+	for _, tt := range tests {
+		if result := options.paintRate("x", tt.rate, deliveryRateGood, deliveryRateWarn); result != tt.expected {
+			t.Errorf("paintRate(%v): expected %q, got %q", tt.rate, tt.expected, result)
+		}
+	}
+
+	var disabled reportOptions
+	if result := disabled.paintRate("x", 40, deliveryRateGood, deliveryRateWarn); result != "x" {
+		t.Errorf("Expected no color when disabled, got %q", result)
+	}
+	if result := options.paintShare("0", 0, 0, deliveryRateGood, deliveryRateWarn); result != "0" {
+		t.Errorf("Expected no color without a base, got %q", result)
+	}
+}
+
+func TestTableFormatter_FormatColor(t *testing.T) {
+	formatter := NewTableFormatter()
+	formatter.SetColor(true)
+	loc, _ := time.LoadLocation("UTC")
+
+	response := &models.AnalyticsResponse{ID: "932157148829117"}
+	response.Analytics.Granularity = "DAY"
+	response.Analytics.DataPoints = []models.DataPoint{
+		{Start: 1750474800, End: 1750561200, Sent: 100, Delivered: 99},
+		{Start: 1750561200, End: 1750647600, Sent: 100, Delivered: 50},
+	}
+
+	result := formatter.Format(response, loc)
+
+	if !strings.Contains(result, ansiGreen+"99"+ansiReset+" │") {
+		t.Errorf("Expected green delivered cell, got:\n%s", result)
+	}
+	if !strings.Contains(result, ansiRed+"50"+ansiReset+" │") {
+		t.Errorf("Expected red delivered cell, got:\n%s", result)
+	}
+
+	// Colored and plain tables share the same layout
+	plain := NewTableFormatter().Format(response, loc)
+	if stripped := strings.NewReplacer(ansiGreen, "", ansiRed, "", ansiReset, "").Replace(result); stripped != plain {
+		t.Errorf("Expected colors not to change the layout:\n%s\n%s", stripped, plain)
+	}
+}
+
+func TestListFormatter_FormatListColor(t *testing.T) {
+	formatter := NewListFormatter()
+	formatter.SetColor(true)
+
+	response := &models.TemplateListResponse{
+		Data: []models.MessageTemplate{
+			{ID: "1", Name: "welcome", Language: "en_US", Status: "APPROVED", Category: "UTILITY"},
+			{ID: "2", Name: "promo", Language: "pt_BR", Status: "REJECTED", Category: "MARKETING"},
+		},
+	}
+
+	result := formatter.FormatList(response)
+
+	for _, expected := range []string{ansiGreen + "APPROVED" + ansiReset, ansiRed + "REJECTED" + ansiReset} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Formatted output doesn't contain expected string: %q\n%s", expected, result)
+		}
+	}
+}
+
+func TestTruncateString_ANSI(t *testing.T) {
+	colored := ansiRed + "PENDING_DELETION" + ansiReset
+
+	if result := displayWidth(colored); result != 16 {
+		t.Errorf("Expected width 16, got %d", result)
+	}
+
+	expected := ansiRed + "PENDI..." + ansiReset
+	if result := truncateString(colored, 8); result != expected {
+		t.Errorf("Expected %q, got %q", expected, result)
+	}
+}
+
+func TestPlain(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"📊 WhatsApp Analytics Report\n", "WhatsApp Analytics Report\n"},
+		{"   ℹ️  Note: Delivered messages may arrive late\n", "   Note: Delivered messages may arrive late\n"},
+		{"   📤 Total Sent: 1.2K\n", "   Total Sent: 1.2K\n"},
+		{"promoção • 50%", "promoção • 50%"},
+	}
+
+	for _, tt := range tests {
+		if result := Plain(tt.input); result != tt.expected {
+			t.Errorf("Plain(%q): expected %q, got %q", tt.input, tt.expected, result)
+		}
+	}
+}
@@ -0,0 +1,296 @@
+package formatter
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"wppanalyticscli/internal/datetime"
+	"wppanalyticscli/internal/models"
+)
+
+// ComparisonFormatter formats analytics for the current window side by side
+// with a comparison window, including absolute and percentage deltas
+type ComparisonFormatter struct {
+	reportOptions
+	previous *Window
+}
+
+// NewComparisonFormatter creates a new comparison formatter
+func NewComparisonFormatter() *ComparisonFormatter {
+	return &ComparisonFormatter{}
+}
+
+// SetPreviousWindow records the comparison window so it is echoed in the report header
+func (f *ComparisonFormatter) SetPreviousWindow(start, end int64) {
+	f.previous = &Window{Start: start, End: end}
+}
+
+// FormatAnalytics compares two analytics responses bucket by bucket
+func (f *ComparisonFormatter) FormatAnalytics(current, previous *models.AnalyticsResponse, loc *time.Location) string {
+	var output strings.Builder
+
+	output.WriteString(fmt.Sprintf("📱 WhatsApp Business Account: %s\n", current.ID))
+	output.WriteString(fmt.Sprintf("⏱️  Granularity: %s\n", current.Analytics.Granularity))
+	f.writeWindow(&output, loc)
+	f.writePreviousWindow(&output, loc)
+	output.WriteString(fmt.Sprintf("🌎 Timezone: %s\n\n", loc.String()))
+
+	currentPoints := current.Analytics.DataPoints
+	previousPoints := previous.Analytics.DataPoints
+
+	if len(currentPoints) == 0 && len(previousPoints) == 0 {
+		output.WriteString("❌ No data points found.\n")
+		return output.String()
+	}
+
+	table := newTextTable(
+		tableColumn{header: "Period", width: 12},
+		tableColumn{header: "Sent", width: 9, align: alignRight},
+		tableColumn{header: "Prev", width: 9, align: alignRight},
+		tableColumn{header: "Δ", width: 9, align: alignRight},
+		tableColumn{header: "Δ%", width: 8, align: alignRight},
+		tableColumn{header: "Delivered", width: 9, align: alignRight},
+		tableColumn{header: "Prev", width: 9, align: alignRight},
+		tableColumn{header: "Δ", width: 9, align: alignRight},
+		tableColumn{header: "Δ%", width: 8, align: alignRight},
+	)
+
+	var totalSent, totalDelivered, prevSent, prevDelivered int
+
+	rows := len(currentPoints)
+	if len(previousPoints) > rows {
+		rows = len(previousPoints)
+	}
+
+	for i := 0; i < rows; i++ {
+		period := "-"
+		var cur, prev *models.DataPoint
+		if i < len(currentPoints) {
+			cur = &currentPoints[i]
+			period, _ = formatTimeRange(cur.Start, cur.End, loc, current.Analytics.Granularity)
+			totalSent += cur.Sent
+			totalDelivered += cur.Delivered
+		}
+		if i < len(previousPoints) {
+			prev = &previousPoints[i]
+			prevSent += prev.Sent
+			prevDelivered += prev.Delivered
+		}
+
+		var curSent, curDelivered, pSent, pDelivered string = "-", "-", "-", "-"
+		var dSent, dSentPct, dDelivered, dDeliveredPct string = "-", "-", "-", "-"
+		if cur != nil {
+			curSent, curDelivered = f.count(cur.Sent), f.count(cur.Delivered)
+		}
+		if prev != nil {
+			pSent, pDelivered = f.count(prev.Sent), f.count(prev.Delivered)
+		}
+		if cur != nil && prev != nil {
+			dSent, dSentPct = f.formatDelta(cur.Sent, prev.Sent), f.formatDeltaPercent(float64(cur.Sent), float64(prev.Sent))
+			dDelivered, dDeliveredPct = f.formatDelta(cur.Delivered, prev.Delivered), f.formatDeltaPercent(float64(cur.Delivered), float64(prev.Delivered))
+		}
+
+		table.addRow(period, curSent, pSent, dSent, dSentPct, curDelivered, pDelivered, dDelivered, dDeliveredPct)
+	}
+
+	f.renderTable(&output, table)
+
+	output.WriteString("\n📈 Summary (current vs previous):\n")
+	output.WriteString(fmt.Sprintf("   📤 Sent: %s\n", f.formatCountComparison(totalSent, prevSent)))
+	output.WriteString(fmt.Sprintf("   📥 Delivered: %s\n", f.formatCountComparison(totalDelivered, prevDelivered)))
+
+	return output.String()
+}
+
+// FormatTemplate compares two template analytics responses per template
+func (f *ComparisonFormatter) FormatTemplate(current, previous *models.TemplateAnalyticsResponse, loc *time.Location) string {
+	var output strings.Builder
+
+	currentPoints := templateDataPoints(current)
+	previousPoints := templateDataPoints(previous)
+
+	output.WriteString("📊 Template Analytics Comparison\n")
+	f.writeWindow(&output, loc)
+	f.writePreviousWindow(&output, loc)
+	output.WriteString(fmt.Sprintf("🌎 Timezone: %s\n\n", loc.String()))
+
+	if len(currentPoints) == 0 && len(previousPoints) == 0 {
+		output.WriteString("❌ No template analytics data found.\n")
+		return output.String()
+	}
+
+	// Totals per template, in order of first appearance
+	var templateIDs []string
+	seen := make(map[string]bool)
+	currentByTemplate := make(map[string]*templateTotals)
+	previousByTemplate := make(map[string]*templateTotals)
+	collect := func(points []models.TemplateDataPoint, totals map[string]*templateTotals) {
+		for _, dp := range points {
+			if !seen[dp.TemplateID] {
+				seen[dp.TemplateID] = true
+				templateIDs = append(templateIDs, dp.TemplateID)
+			}
+			if totals[dp.TemplateID] == nil {
+				totals[dp.TemplateID] = &templateTotals{}
+			}
+			totals[dp.TemplateID].add(dp)
+		}
+	}
+	collect(currentPoints, currentByTemplate)
+	collect(previousPoints, previousByTemplate)
+
+	table := newTextTable(
+		tableColumn{header: "Template ID", width: 15, flex: true, min: 10},
+		tableColumn{header: "Metric", width: 9},
+		tableColumn{header: "Current", width: 10, align: alignRight},
+		tableColumn{header: "Previous", width: 10, align: alignRight},
+		tableColumn{header: "Δ", width: 10, align: alignRight},
+		tableColumn{header: "Δ%", width: 8, align: alignRight},
+	)
+
+	for i, templateID := range templateIDs {
+		cur := totalsOrZero(currentByTemplate[templateID])
+		prev := totalsOrZero(previousByTemplate[templateID])
+
+		for j, row := range f.comparisonRows(cur, prev) {
+			label := ""
+			if j == 0 {
+				label = templateID
+			}
+			table.addRow(label, row.metric, row.current, row.previous, row.delta, row.deltaPercent)
+		}
+
+		if i < len(templateIDs)-1 {
+			table.addSeparator()
+		}
+	}
+
+	f.renderTable(&output, table)
+
+	cur := sumTemplatePoints(currentPoints)
+	prev := sumTemplatePoints(previousPoints)
+
+	output.WriteString("\n📈 Summary (current vs previous):\n")
+	output.WriteString(fmt.Sprintf("   📤 Sent: %s\n", f.formatCountComparison(cur.Sent, prev.Sent)))
+	output.WriteString(fmt.Sprintf("   📥 Delivered: %s\n", f.formatCountComparison(cur.Delivered, prev.Delivered)))
+	output.WriteString(fmt.Sprintf("   👀 Read: %s\n", f.formatCountComparison(cur.Read, prev.Read)))
+	output.WriteString(fmt.Sprintf("   👆 Clicked: %s\n", f.formatCountComparison(cur.Clicked, prev.Clicked)))
+	output.WriteString(fmt.Sprintf("   💰 Cost: %s vs %s (%s, %s)\n", f.cost(cur.Cost), f.cost(prev.Cost),
+		f.formatCostDelta(cur.Cost, prev.Cost), f.formatDeltaPercent(cur.Cost, prev.Cost)))
+
+	return output.String()
+}
+
+// writePreviousWindow writes the comparison window below the current one
+func (f *ComparisonFormatter) writePreviousWindow(output *strings.Builder, loc *time.Location) {
+	if f.previous == nil {
+		return
+	}
+
+	start := datetime.ConvertEpochToLocal(f.previous.Start, loc)
+	end := datetime.ConvertEpochToLocal(f.previous.End, loc)
+	output.WriteString(fmt.Sprintf("🔁 Compared with: %s → %s\n", start.Format("2006-01-02 15:04 MST"), end.Format("2006-01-02 15:04 MST")))
+	output.WriteString(fmt.Sprintf("   UTC: %s → %s\n", start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339)))
+}
+
+// comparisonRow is one metric line of the template comparison table
+type comparisonRow struct {
+	metric       string
+	current      string
+	previous     string
+	delta        string
+	deltaPercent string
+}
+
+// comparisonRows builds the metric lines comparing two template totals
+func (o *reportOptions) comparisonRows(cur, prev templateTotals) []comparisonRow {
+	counts := []struct {
+		metric   string
+		current  int
+		previous int
+	}{
+		{"Sent", cur.Sent, prev.Sent},
+		{"Delivered", cur.Delivered, prev.Delivered},
+		{"Read", cur.Read, prev.Read},
+		{"Clicked", cur.Clicked, prev.Clicked},
+	}
+
+	var rows []comparisonRow
+	for _, count := range counts {
+		rows = append(rows, comparisonRow{
+			metric:       count.metric,
+			current:      o.count(count.current),
+			previous:     o.count(count.previous),
+			delta:        o.formatDelta(count.current, count.previous),
+			deltaPercent: o.formatDeltaPercent(float64(count.current), float64(count.previous)),
+		})
+	}
+
+	rows = append(rows, comparisonRow{
+		metric:       "Cost",
+		current:      o.cost(cur.Cost),
+		previous:     o.cost(prev.Cost),
+		delta:        o.formatCostDelta(cur.Cost, prev.Cost),
+		deltaPercent: o.formatDeltaPercent(cur.Cost, prev.Cost),
+	})
+
+	return rows
+}
+
+// templateDataPoints returns the data points of the first data object, if any
+func templateDataPoints(response *models.TemplateAnalyticsResponse) []models.TemplateDataPoint {
+	if response == nil || len(response.Data) == 0 {
+		return nil
+	}
+	return response.Data[0].DataPoints
+}
+
+// totalsOrZero dereferences totals, treating a missing template as all zeros
+func totalsOrZero(totals *templateTotals) templateTotals {
+	if totals == nil {
+		return templateTotals{}
+	}
+	return *totals
+}
+
+// formatCountComparison formats "current vs previous (+delta, +pct%)"
+func (o *reportOptions) formatCountComparison(current, previous int) string {
+	return fmt.Sprintf("%s vs %s (%s, %s)", o.count(current), o.count(previous),
+		o.formatDelta(current, previous), o.formatDeltaPercent(float64(current), float64(previous)))
+}
+
+// formatDelta formats the signed difference between two counts
+func (o *reportOptions) formatDelta(current, previous int) string {
+	delta := current - previous
+	switch {
+	case delta > 0:
+		return "+" + o.count(delta)
+	case delta < 0:
+		return o.count(delta)
+	default:
+		return "0"
+	}
+}
+
+// formatCostDelta formats the signed difference between two costs
+func (o *reportOptions) formatCostDelta(current, previous float64) string {
+	delta := current - previous
+	if delta < 0 {
+		return o.cost(delta)
+	}
+	return "+" + o.cost(delta)
+}
+
+// formatDeltaPercent formats the relative change from previous to current
+func (o *reportOptions) formatDeltaPercent(current, previous float64) string {
+	if previous == 0 {
+		if current == 0 {
+			return o.percent(0)
+		}
+		return "n/a"
+	}
+	change := (current - previous) / math.Abs(previous) * 100
+	return o.num().SignedPercent(change)
+}
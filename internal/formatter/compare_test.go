@@ -0,0 +1,89 @@
+package formatter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"wppanalyticscli/internal/models"
+)
+
+func TestComparisonFormatter_FormatAnalytics(t *testing.T) {
+	formatter := NewComparisonFormatter()
+
+	current := &models.AnalyticsResponse{ID: "932157148829117"}
+	current.Analytics.Granularity = "DAY"
+	current.Analytics.DataPoints = []models.DataPoint{
+		{Start: 1750474800, End: 1750561200, Sent: 120, Delivered: 110},
+		{Start: 1750561200, End: 1750647600, Sent: 80, Delivered: 70},
+	}
+
+	previous := &models.AnalyticsResponse{ID: "932157148829117"}
+	previous.Analytics.Granularity = "DAY"
+	previous.Analytics.DataPoints = []models.DataPoint{
+		{Start: 1749870000, End: 1749956400, Sent: 100, Delivered: 100},
+		{Start: 1749956400, End: 1750042800, Sent: 100, Delivered: 100},
+	}
+
+	loc, _ := time.LoadLocation("UTC")
+	result := formatter.FormatAnalytics(current, previous, loc)
+
+	expectedStrings := []string{
+		"📈 Summary (current vs previous):",
+		"📤 Sent: 200 vs 200 (0, +0.0%)",
+		"📥 Delivered: 180 vs 200 (-20, -10.0%)",
+		"+20.0%",
+		"-20.0%",
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Formatted output doesn't contain expected string: %s\n%s", expected, result)
+		}
+	}
+}
+
+func TestComparisonFormatter_FormatTemplate(t *testing.T) {
+	formatter := NewComparisonFormatter()
+
+	current := &models.TemplateAnalyticsResponse{
+		Data: []models.TemplateAnalyticsData{{
+			Granularity: "DAILY",
+			DataPoints: []models.TemplateDataPoint{
+				{
+					TemplateID: "1026573095658757", Sent: 150, Delivered: 140, Read: 70,
+					Clicked: []models.ClickedAction{{Type: "quick_reply_button", ButtonContent: "Quero negociar", Count: 14}},
+					Cost:    []models.CostMetric{{Type: "amount_spent", Value: 3.00}},
+				},
+			},
+		}},
+	}
+
+	previous := &models.TemplateAnalyticsResponse{
+		Data: []models.TemplateAnalyticsData{{
+			Granularity: "DAILY",
+			DataPoints: []models.TemplateDataPoint{
+				{
+					TemplateID: "1026573095658757", Sent: 100, Delivered: 100, Read: 50,
+					Cost: []models.CostMetric{{Type: "amount_spent", Value: 2.00}},
+				},
+			},
+		}},
+	}
+
+	loc, _ := time.LoadLocation("UTC")
+	result := formatter.FormatTemplate(current, previous, loc)
+
+	expectedStrings := []string{
+		"📤 Sent: 150 vs 100 (+50, +50.0%)",
+		"👀 Read: 70 vs 50 (+20, +40.0%)",
+		"👆 Clicked: 14 vs 0 (+14, n/a)",
+		"💰 Cost: $3.00 vs $2.00 (+$1.00, +50.0%)",
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Formatted output doesn't contain expected string: %s\n%s", expected, result)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package formatter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"wppanalyticscli/internal/models"
+)
+
+// costBreakdown holds every cost metric for a set of template data points.
+// Unit costs are recomputed from the totals; derived marks unit costs the API
+// did not report for any of the points.
+type costBreakdown struct {
+	spent        float64
+	perDelivered float64
+	perRead      float64
+	perClick     float64
+	perURLClick  float64
+	derived      map[string]bool
+	other        map[string]float64 // unknown cost types, averaged over the points reporting them
+}
+
+// buildCostBreakdown aggregates the cost metrics of points
+func buildCostBreakdown(points []models.TemplateDataPoint) costBreakdown {
+	breakdown := costBreakdown{
+		derived: map[string]bool{
+			"cost_per_delivered":        true,
+			"cost_per_read":             true,
+			"cost_per_click":            true,
+			"cost_per_url_button_click": true,
+		},
+		other: make(map[string]float64),
+	}
+
+	totals := sumTemplatePoints(points)
+	urlClicks := 0
+	otherCounts := make(map[string]int)
+
+	for _, dp := range points {
+		for _, clicked := range dp.Clicked {
+			if clicked.Type == "url_button" {
+				urlClicks += clicked.Count
+			}
+		}
+		for _, cost := range dp.Cost {
+			if cost.Type == "amount_spent" {
+				continue
+			}
+			if _, known := breakdown.derived[cost.Type]; known {
+				breakdown.derived[cost.Type] = false
+				continue
+			}
+			breakdown.other[cost.Type] += cost.Value
+			otherCounts[cost.Type]++
+		}
+	}
+
+	for costType, count := range otherCounts {
+		breakdown.other[costType] /= float64(count)
+	}
+
+	breakdown.spent = totals.Cost
+	breakdown.perDelivered = unitCost(totals.Cost, totals.Delivered)
+	breakdown.perRead = unitCost(totals.Cost, totals.Read)
+	breakdown.perClick = unitCost(totals.Cost, totals.Clicked)
+	breakdown.perURLClick = unitCost(totals.Cost, urlClicks)
+
+	return breakdown
+}
+
+// unitCost divides an amount by a count, returning 0 when there is nothing to divide by
+func unitCost(amount float64, count int) float64 {
+	if count <= 0 {
+		return 0
+	}
+	return amount / float64(count)
+}
+
+// costBreakdownHeaders are the column titles of the cost breakdown table
+var costBreakdownHeaders = []string{"Template ID", "Spent", "Per Delivered", "Per Read", "Per Click", "Per URL Click"}
+
+// costBreakdownRows returns one cost breakdown row per group and, for several
+// groups, a grand total row. derived reports whether any unit cost is marked "*".
+func (o *reportOptions) costBreakdownRows(groups []templateGroup, points []models.TemplateDataPoint) (rows [][]string, total []string, derived bool) {
+	row := func(label string, breakdown costBreakdown) []string {
+		unit := func(costType string, value float64) string {
+			if value == 0 {
+				return "-"
+			}
+			if breakdown.derived[costType] {
+				derived = true
+				return o.costPerUnit(value) + "*"
+			}
+			return o.costPerUnit(value)
+		}
+		return []string{
+			label,
+			o.cost(breakdown.spent),
+			unit("cost_per_delivered", breakdown.perDelivered),
+			unit("cost_per_read", breakdown.perRead),
+			unit("cost_per_click", breakdown.perClick),
+			unit("cost_per_url_button_click", breakdown.perURLClick),
+		}
+	}
+
+	for _, group := range groups {
+		rows = append(rows, row(group.key, buildCostBreakdown(group.points)))
+	}
+	if len(groups) > 1 {
+		total = row("Σ Total", buildCostBreakdown(points))
+	}
+
+	return rows, total, derived
+}
+
+// otherCostItems lists cost types other than the known unit costs, sorted by type
+func (o *reportOptions) otherCostItems(points []models.TemplateDataPoint) []string {
+	other := buildCostBreakdown(points).other
+
+	var costTypes []string
+	for costType := range other {
+		costTypes = append(costTypes, costType)
+	}
+	sort.Strings(costTypes)
+
+	var items []string
+	for _, costType := range costTypes {
+		items = append(items, fmt.Sprintf("%s: %s", costType, o.costPerUnit(other[costType])))
+	}
+	return items
+}
+
+// writeCostBreakdown writes a table with every cost metric per group and the grand total
+func (o *reportOptions) writeCostBreakdown(output *strings.Builder, groups []templateGroup, points []models.TemplateDataPoint) {
+	rows, total, derived := o.costBreakdownRows(groups, points)
+
+	table := newTextTable(
+		tableColumn{header: costBreakdownHeaders[0], width: 15, flex: true, min: 10},
+		tableColumn{header: costBreakdownHeaders[1], width: 11, align: alignRight},
+		tableColumn{header: costBreakdownHeaders[2], width: 13, align: alignRight},
+		tableColumn{header: costBreakdownHeaders[3], width: 13, align: alignRight},
+		tableColumn{header: costBreakdownHeaders[4], width: 13, align: alignRight},
+		tableColumn{header: costBreakdownHeaders[5], width: 13, align: alignRight},
+	)
+
+	for _, row := range rows {
+		table.addRow(row...)
+	}
+	if total != nil {
+		table.addSeparator()
+		table.addRow(total...)
+	}
+
+	output.WriteString(fmt.Sprintf("\n💰 Cost Breakdown (%s):\n", o.money().Code))
+	o.renderTable(output, table)
+
+	if derived {
+		output.WriteString("   * derived locally from amount spent\n")
+	}
+
+	if items := o.otherCostItems(points); len(items) > 0 {
+		output.WriteString("   Other cost metrics (average per data point):\n")
+		for _, item := range items {
+			output.WriteString(fmt.Sprintf("   • %s\n", item))
+		}
+	}
+}
+
+// hasCostMetrics reports whether any point carries cost metrics
+func hasCostMetrics(points []models.TemplateDataPoint) bool {
+	for _, dp := range points {
+		if len(dp.Cost) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Currency describes how amounts in a currency are displayed
+type Currency struct {
+	Code     string
+	Symbol   string
+	Decimals int
+}
+
+// currencies lists the display rules for common billing currencies
+var currencies = map[string]Currency{
+	"USD": {Code: "USD", Symbol: "$", Decimals: 2},
+	"BRL": {Code: "BRL", Symbol: "R$", Decimals: 2},
+	"EUR": {Code: "EUR", Symbol: "€", Decimals: 2},
+	"GBP": {Code: "GBP", Symbol: "£", Decimals: 2},
+	"INR": {Code: "INR", Symbol: "₹", Decimals: 2},
+	"MXN": {Code: "MXN", Symbol: "MX$", Decimals: 2},
+	"ARS": {Code: "ARS", Symbol: "AR$", Decimals: 2},
+	"COP": {Code: "COP", Symbol: "COL$", Decimals: 2},
+	"CLP": {Code: "CLP", Symbol: "CLP$", Decimals: 0},
+	"PEN": {Code: "PEN", Symbol: "S/", Decimals: 2},
+	"IDR": {Code: "IDR", Symbol: "Rp", Decimals: 2},
+	"JPY": {Code: "JPY", Symbol: "¥", Decimals: 0},
+	"KRW": {Code: "KRW", Symbol: "₩", Decimals: 0},
+	"AUD": {Code: "AUD", Symbol: "A$", Decimals: 2},
+	"CAD": {Code: "CAD", Symbol: "CA$", Decimals: 2},
+}
+
+// LookupCurrency returns the display rules for an ISO 4217 code; unknown codes
+// are shown with the code as symbol and two decimals, and empty means USD
+func LookupCurrency(code string) Currency {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		code = "USD"
+	}
+	if currency, ok := currencies[code]; ok {
+		return currency
+	}
+	return Currency{Code: code, Symbol: code, Decimals: 2}
+}
+
+// Format formats an amount with the currency symbol and its usual decimals
+func (c Currency) Format(value float64) string {
+	return c.FormatIn(defaultNumbers, value)
+}
+
+// FormatUnit formats a per-unit cost, which needs two more decimals than amounts
+func (c Currency) FormatUnit(value float64) string {
+	return c.FormatUnitIn(defaultNumbers, value)
+}
+
+// FormatIn formats an amount using the separators of a number format
+func (c Currency) FormatIn(nf NumberFormat, value float64) string {
+	return c.format(nf, value, c.Decimals)
+}
+
+// FormatUnitIn formats a per-unit cost using the separators of a number format
+func (c Currency) FormatUnitIn(nf NumberFormat, value float64) string {
+	return c.format(nf, value, c.Decimals+2)
+}
+
+// format places the symbol before the amount, separated by a space for multi-character symbols
+func (c Currency) format(nf NumberFormat, value float64, decimals int) string {
+	sign := ""
+	if value < 0 {
+		sign = "-"
+	}
+	amount := nf.Amount(value, decimals)
+	if len([]rune(c.Symbol)) > 1 {
+		return fmt.Sprintf("%s%s %s", sign, c.Symbol, amount)
+	}
+	return fmt.Sprintf("%s%s%s", sign, c.Symbol, amount)
+}
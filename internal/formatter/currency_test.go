@@ -0,0 +1,33 @@
+package formatter
+
+import "testing"
+
+func TestCurrency_Format(t *testing.T) {
+	tests := []struct {
+		code     string
+		value    float64
+		expected string
+		unit     string
+	}{
+		{"USD", 6.218, "$6.22", "$6.2180"},
+		{"", 6.218, "$6.22", "$6.2180"},
+		{"brl", 6.218, "R$ 6.22", "R$ 6.2180"},
+		{"JPY", 1234.5, "¥1234", "¥1234.50"},
+		{"CHF", 3, "CHF 3.00", "CHF 3.0000"},
+		{"EUR", -1.5, "-€1.50", "-€1.5000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			currency := LookupCurrency(tt.code)
+
+			if result := currency.Format(tt.value); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+
+			if result := currency.FormatUnit(tt.value); result != tt.unit {
+				t.Errorf("Expected unit '%s', got '%s'", tt.unit, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"wppanalyticscli/internal/models"
+)
+
+// CustomFormatter formats reports with a user-supplied Go text/template
+type CustomFormatter struct {
+	reportOptions
+	tmpl *template.Template
+	loc  *time.Location // Timezone of the report being executed, read by the date helpers
+}
+
+// NewCustomFormatter parses a text/template for custom output. See customData for
+// the value it is executed with and customFuncs for the helper functions.
+func NewCustomFormatter(text string) (*CustomFormatter, error) {
+	f := &CustomFormatter{loc: time.UTC}
+	tmpl, err := template.New("format").Funcs(f.customFuncs()).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid format template: %w", err)
+	}
+	f.tmpl = tmpl
+	return f, nil
+}
+
+// customData is the value a custom template is executed with: the decoded
+// response plus totals and rates computed the same way as the built-in reports
+type customData struct {
+	Mode       string      // "analytics", "template" or "list-templates"
+	Response   interface{} // *models.AnalyticsResponse, *models.TemplateAnalyticsResponse or *models.TemplateListResponse
+	Window     *Window
+	Timezone   string
+	Currency   string
+	Totals     customTotals
+	Templates  []customTemplate // template mode, one entry per template ID
+	Statuses   []customCount    // list mode breakdowns, largest count first
+	Categories []customCount
+	Languages  []customCount
+}
+
+// customTotals are summed counts with their rates in percent
+type customTotals struct {
+	Sent         int
+	Delivered    int
+	Read         int
+	Clicked      int
+	Cost         float64
+	DeliveryRate float64
+	ReadRate     float64
+	ClickRate    float64
+}
+
+// customTemplate is the totals of one template
+type customTemplate struct {
+	TemplateID string
+	customTotals
+}
+
+// customCount is one entry of a breakdown
+type customCount struct {
+	Key   string
+	Count int
+}
+
+// Format executes the template over an analytics response
+func (f *CustomFormatter) Format(response *models.AnalyticsResponse, loc *time.Location) (string, error) {
+	data := f.newCustomData("analytics", response, loc)
+	var totals templateTotals
+	for _, dp := range response.Analytics.DataPoints {
+		totals.Sent += dp.Sent
+		totals.Delivered += dp.Delivered
+	}
+	data.Totals = newCustomTotals(totals)
+	return f.execute(data, loc)
+}
+
+// FormatTemplate executes the template over a template analytics response
+func (f *CustomFormatter) FormatTemplate(response *models.TemplateAnalyticsResponse, loc *time.Location) (string, error) {
+	data := f.newCustomData("template", response, loc)
+	if len(response.Data) > 0 {
+		points := response.Data[0].DataPoints
+		data.Totals = newCustomTotals(sumTemplatePoints(points))
+		for _, group := range groupTemplatePoints(points, "template", f.sortBy, loc, response.Data[0].Granularity) {
+			data.Templates = append(data.Templates, customTemplate{TemplateID: group.key, customTotals: newCustomTotals(group.totals)})
+		}
+	}
+	return f.execute(data, loc)
+}
+
+// FormatList executes the template over a template list response
+func (f *CustomFormatter) FormatList(response *models.TemplateListResponse) (string, error) {
+	data := f.newCustomData("list-templates", response, time.UTC)
+	statusCounts := make(map[string]int)
+	categoryCounts := make(map[string]int)
+	languageCounts := make(map[string]int)
+	for _, template := range response.Data {
+		statusCounts[strings.ToUpper(template.Status)]++
+		categoryCounts[strings.ToUpper(template.Category)]++
+		languageCounts[strings.ToUpper(template.Language)]++
+	}
+	data.Statuses = newCustomCounts(statusCounts)
+	data.Categories = newCustomCounts(categoryCounts)
+	data.Languages = newCustomCounts(languageCounts)
+	return f.execute(data, time.UTC)
+}
+
+// newCustomData returns the data shared by every mode
+func (f *CustomFormatter) newCustomData(mode string, response interface{}, loc *time.Location) customData {
+	return customData{
+		Mode:     mode,
+		Response: response,
+		Window:   f.window,
+		Timezone: loc.String(),
+		Currency: f.money().Code,
+	}
+}
+
+// execute runs the template with the date helpers reading the report's timezone
+func (f *CustomFormatter) execute(data customData, loc *time.Location) (string, error) {
+	f.loc = loc
+	var output strings.Builder
+	if err := f.tmpl.Execute(&output, data); err != nil {
+		return "", fmt.Errorf("format template: %w", err)
+	}
+	return output.String(), nil
+}
+
+// newCustomTotals adds rates to template totals
+func newCustomTotals(totals templateTotals) customTotals {
+	return customTotals{
+		Sent:         totals.Sent,
+		Delivered:    totals.Delivered,
+		Read:         totals.Read,
+		Clicked:      totals.Clicked,
+		Cost:         totals.Cost,
+		DeliveryRate: percentage(totals.Delivered, totals.Sent),
+		ReadRate:     percentage(totals.Read, totals.Delivered),
+		ClickRate:    percentage(totals.Clicked, totals.Delivered),
+	}
+}
+
+// newCustomCounts turns counts into a breakdown, largest count first, then by key
+func newCustomCounts(counts map[string]int) []customCount {
+	var entries []customCount
+	for _, key := range sortedCountKeys(counts) {
+		entries = append(entries, customCount{Key: key, Count: counts[key]})
+	}
+	return entries
+}
+
+// customFuncs returns the helper functions available to custom templates:
+//
+//	count, exact, short    format a count in the report, exact or short style
+//	number x decimals      format a number with fixed decimals
+//	percent x              format a percentage
+//	rate part whole        part as a percentage of whole (0 when whole is 0)
+//	money x, unitCost x    format an amount in the report currency
+//	date, datetime epoch   format a Unix epoch in the report timezone
+//	time epoch layout      format a Unix epoch with a Go layout
+//	in tz epoch            convert a Unix epoch to a time in another timezone
+//	upper, lower, join     string helpers
+func (f *CustomFormatter) customFuncs() template.FuncMap {
+	return template.FuncMap{
+		"count": func(n interface{}) (string, error) {
+			value, err := toFloat(n)
+			return f.count(int(value)), err
+		},
+		"exact": func(n interface{}) (string, error) {
+			value, err := toFloat(n)
+			nf := f.num()
+			nf.Short = false
+			return nf.Count(int(value)), err
+		},
+		"short": func(n interface{}) (string, error) {
+			value, err := toFloat(n)
+			nf := f.num()
+			nf.Short = true
+			return nf.Count(int(value)), err
+		},
+		"number": func(x interface{}, decimals int) (string, error) {
+			value, err := toFloat(x)
+			return f.num().Fixed(value, decimals), err
+		},
+		"percent": func(x interface{}) (string, error) {
+			value, err := toFloat(x)
+			return f.percent(value), err
+		},
+		"rate": func(part, whole interface{}) (float64, error) {
+			partValue, err := toFloat(part)
+			if err != nil {
+				return 0, err
+			}
+			wholeValue, err := toFloat(whole)
+			if err != nil || wholeValue == 0 {
+				return 0, err
+			}
+			return partValue / wholeValue * 100, nil
+		},
+		"money": func(x interface{}) (string, error) {
+			value, err := toFloat(x)
+			return f.cost(value), err
+		},
+		"unitCost": func(x interface{}) (string, error) {
+			value, err := toFloat(x)
+			return f.costPerUnit(value), err
+		},
+		"date": func(epoch int64) string {
+			return time.Unix(epoch, 0).In(f.loc).Format("2006-01-02")
+		},
+		"datetime": func(epoch int64) string {
+			return time.Unix(epoch, 0).In(f.loc).Format(time.RFC3339)
+		},
+		"time": func(epoch int64, layout string) string {
+			return time.Unix(epoch, 0).In(f.loc).Format(layout)
+		},
+		"in": func(tz string, epoch int64) (time.Time, error) {
+			zone, err := time.LoadLocation(tz)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("invalid timezone '%s'", tz)
+			}
+			return time.Unix(epoch, 0).In(zone), nil
+		},
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"join":  strings.Join,
+	}
+}
+
+// toFloat converts a template number argument to float64, failing on anything
+// that is not a number so a typo in a template is reported instead of printing 0
+func toFloat(v interface{}) (float64, error) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), nil
+	case int32:
+		return float64(n), nil
+	case int64:
+		return float64(n), nil
+	case float32:
+		return float64(n), nil
+	case float64:
+		return n, nil
+	default:
+		return 0, fmt.Errorf("expected a number, got %T %v", v, v)
+	}
+}
@@ -0,0 +1,133 @@
+package formatter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"wppanalyticscli/internal/models"
+)
+
+func TestCustomFormatter_Format(t *testing.T) {
+	formatter, err := NewCustomFormatter(`{{range .Response.Analytics.DataPoints}}{{date .Start}},{{.Sent}},{{.Delivered}}
+{{end}}total,{{exact .Totals.Sent}},{{percent .Totals.DeliveryRate}}
+`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	loc, _ := time.LoadLocation("America/Sao_Paulo")
+	response := &models.AnalyticsResponse{ID: "932157148829117"}
+	response.Analytics.DataPoints = []models.DataPoint{
+		{Start: 1750388400, End: 1750474800, Sent: 1000, Delivered: 950},
+		{Start: 1750474800, End: 1750561200, Sent: 523, Delivered: 500},
+	}
+
+	result, err := formatter.Format(response, loc)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "2025-06-20,1000,950\n2025-06-21,523,500\ntotal,1523,95.2%\n"
+	if result != expected {
+		t.Errorf("Expected %q, got %q", expected, result)
+	}
+}
+
+func TestCustomFormatter_FormatTemplate(t *testing.T) {
+	formatter, err := NewCustomFormatter(`{{.Mode}} {{.Currency}}
+{{range .Templates}}{{.TemplateID}}: {{count .Sent}} sent, read {{percent .ReadRate}}, {{money .Cost}}
+{{end}}`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	formatter.SetCurrency("BRL")
+	formatter.SetSort("sent")
+
+	loc, _ := time.LoadLocation("UTC")
+	result, err := formatter.FormatTemplate(groupingTestResponse(), loc)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "template BRL\n2222: 310 sent, read 36.2%, R$ 3.10\n1111: 150 sent, read 50.0%, R$ 1.50\n"
+	if result != expected {
+		t.Errorf("Expected %q, got %q", expected, result)
+	}
+}
+
+func TestCustomFormatter_FormatList(t *testing.T) {
+	formatter, err := NewCustomFormatter(`{{len .Response.Data}} templates{{range .Statuses}}; {{.Key}}={{.Count}}{{end}}`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	result, err := formatter.FormatList(goldenListResponse())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "5 templates; APPROVED=2; PAUSED=1; PENDING=1; REJECTED=1"
+	if result != expected {
+		t.Errorf("Expected %q, got %q", expected, result)
+	}
+}
+
+func TestCustomFormatter_Helpers(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"short", `{{short 1234567}}`, "1.2M"},
+		{"exact", `{{exact 1234567}}`, "1,234,567"},
+		{"number", `{{number 3.14159 2}}`, "3.14"},
+		{"rate", `{{percent (rate 45 90)}}`, "50.0%"},
+		{"rate of zero", `{{rate 5 0}}`, "0"},
+		{"unit cost", `{{unitCost 0.0125}}`, "$0.0125"},
+		{"timezone", `{{(in "Asia/Tokyo" 1750388400).Format "2006-01-02 15:04"}}`, "2025-06-20 12:00"},
+		{"time layout", `{{time 1750388400 "Jan 2 15:04"}}`, "Jun 20 03:00"},
+		{"strings", `{{upper "approved"}} {{lower "MARKETING"}}`, "APPROVED marketing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatter, err := NewCustomFormatter(tt.text)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			formatter.SetNumbers(NumberFormat{Short: true, Thousands: ",", Decimal: "."})
+
+			result, err := formatter.FormatList(&models.TemplateListResponse{})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCustomFormatter_Errors(t *testing.T) {
+	if _, err := NewCustomFormatter(`{{range .Templates}}`); err == nil || !strings.Contains(err.Error(), "invalid format template") {
+		t.Errorf("Expected parse error, got %v", err)
+	}
+
+	formatter, err := NewCustomFormatter(`{{in "Mars/Olympus" 0}}`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := formatter.FormatList(&models.TemplateListResponse{}); err == nil || !strings.Contains(err.Error(), "invalid timezone") {
+		t.Errorf("Expected timezone error, got %v", err)
+	}
+
+	formatter, err = NewCustomFormatter(`{{range .Response.Data}}{{count .Name}}{{end}}`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	list := &models.TemplateListResponse{Data: []models.MessageTemplate{{ID: "1", Name: "welcome"}}}
+	if _, err := formatter.FormatList(list); err == nil || !strings.Contains(err.Error(), "expected a number, got string") {
+		t.Errorf("Expected number error, got %v", err)
+	}
+}
@@ -0,0 +1,376 @@
+package formatter
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"wppanalyticscli/internal/datetime"
+	"wppanalyticscli/internal/models"
+)
+
+// DocumentFormatter formats every report mode as a standalone document
+type DocumentFormatter struct {
+	reportOptions
+	render func(doc document) string
+}
+
+// Format formats the analytics response as a document
+func (f *DocumentFormatter) Format(response *models.AnalyticsResponse, loc *time.Location) string {
+	return f.render(f.analyticsDocument(response, loc))
+}
+
+// FormatTemplate formats the template analytics response as a document
+func (f *DocumentFormatter) FormatTemplate(response *models.TemplateAnalyticsResponse, loc *time.Location) string {
+	return f.render(f.templateDocument(response, loc))
+}
+
+// FormatList formats the template list response as a document
+func (f *DocumentFormatter) FormatList(response *models.TemplateListResponse) string {
+	return f.render(f.listDocument(response))
+}
+
+// document is a format-neutral report, rendered as markdown or HTML
+type document struct {
+	title    string
+	details  []detail
+	sections []section
+}
+
+// detail is a labelled value in the document header
+type detail struct {
+	label string
+	value string
+}
+
+// section is a titled part of the document with a chart, a table, a list and
+// notes, rendered in that order. Only the HTML renderer draws charts.
+type section struct {
+	title string
+	chart *chart
+	table *docTable
+	items []string
+	notes []string
+}
+
+// docTable is a table with right-aligned numeric columns and optional total rows
+type docTable struct {
+	headers []string
+	numeric []bool
+	rows    []docRow
+}
+
+// docRow is one table row; total rows are emphasized
+type docRow struct {
+	cells []string
+	total bool
+}
+
+// addRow appends a row to the table
+func (t *docTable) addRow(total bool, cells ...string) {
+	t.rows = append(t.rows, docRow{cells: cells, total: total})
+}
+
+// windowDetails returns the requested window in the display timezone and UTC
+func (o *reportOptions) windowDetails(loc *time.Location) []detail {
+	if o.window == nil {
+		return nil
+	}
+
+	start := datetime.ConvertEpochToLocal(o.window.Start, loc)
+	end := datetime.ConvertEpochToLocal(o.window.End, loc)
+	return []detail{
+		{"Window", fmt.Sprintf("%s → %s", start.Format("2006-01-02 15:04 MST"), end.Format("2006-01-02 15:04 MST"))},
+		{"UTC", fmt.Sprintf("%s → %s", start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339))},
+	}
+}
+
+// analyticsDocument builds the document for an analytics response
+func (o *reportOptions) analyticsDocument(response *models.AnalyticsResponse, loc *time.Location) document {
+	doc := document{title: fmt.Sprintf("WhatsApp Business Account %s", response.ID)}
+	doc.details = append(doc.details,
+		detail{"Phone Numbers", strings.Join(response.Analytics.PhoneNumbers, ", ")},
+		detail{"Granularity", response.Analytics.Granularity},
+		detail{"Data Points", o.count(len(response.Analytics.DataPoints))})
+	doc.details = append(doc.details, o.windowDetails(loc)...)
+	doc.details = append(doc.details, detail{"Timezone", loc.String()})
+
+	if len(response.Analytics.DataPoints) == 0 {
+		doc.sections = append(doc.sections, section{notes: []string{"No data points found."}})
+		return doc
+	}
+
+	table := &docTable{
+		headers: []string{"Date", "Time Range", "Sent", "Delivered", "Delivery %"},
+		numeric: []bool{false, false, true, true, true},
+	}
+
+	totalSent := 0
+	totalDelivered := 0
+	for _, dp := range sortedDataPoints(response.Analytics.DataPoints, o.sortBy) {
+		date, timeRange := formatTimeRange(dp.Start, dp.End, loc, response.Analytics.Granularity)
+		table.addRow(false, date, timeRange, o.count(dp.Sent), o.count(dp.Delivered), o.percent(percentage(dp.Delivered, dp.Sent)))
+		totalSent += dp.Sent
+		totalDelivered += dp.Delivered
+	}
+	table.addRow(true, "Total", "", o.count(totalSent), o.count(totalDelivered), o.percent(percentage(totalDelivered, totalSent)))
+
+	doc.sections = append(doc.sections,
+		section{title: "Sent and Delivered", chart: o.analyticsChart(response, loc)},
+		section{title: "Analytics", table: table},
+		section{
+			title: "Summary",
+			items: []string{
+				fmt.Sprintf("Total Sent: %s", o.count(totalSent)),
+				fmt.Sprintf("Total Delivered: %s", o.count(totalDelivered)),
+			},
+			notes: []string{"Delivered messages may arrive after the reporting period."},
+		})
+
+	return doc
+}
+
+// templateDocument builds the document for a template analytics response
+func (o *reportOptions) templateDocument(response *models.TemplateAnalyticsResponse, loc *time.Location) document {
+	doc := document{title: "Template Analytics Report"}
+
+	if len(response.Data) == 0 {
+		doc.sections = append(doc.sections, section{notes: []string{"No template analytics data found."}})
+		return doc
+	}
+
+	data := response.Data[0]
+	doc.details = append(doc.details,
+		detail{"Granularity", strings.ToUpper(data.Granularity)},
+		detail{"Product Type", strings.ToUpper(data.ProductType)},
+		detail{"Data Points", o.count(len(data.DataPoints))})
+	doc.details = append(doc.details, o.windowDetails(loc)...)
+	doc.details = append(doc.details, detail{"Timezone", loc.String()})
+
+	if len(data.DataPoints) == 0 {
+		doc.sections = append(doc.sections, section{notes: []string{"No data points found."}})
+		return doc
+	}
+
+	groupBy := o.grouping()
+	groups := groupTemplatePoints(data.DataPoints, groupBy, o.sortBy, loc, data.Granularity)
+
+	table := &docTable{
+		headers: []string{"Date", "Template ID", "Sent", "Delivered", "Read", "Clicked", "Cost", "Click Rate %"},
+		numeric: []bool{false, false, true, true, true, true, true, true},
+	}
+	addTotalsRow := func(total bool, date, templateID string, totals templateTotals) {
+		table.addRow(total, date, templateID,
+			o.count(totals.Sent), o.count(totals.Delivered), o.count(totals.Read), o.count(totals.Clicked),
+			o.cost(totals.Cost), o.percent(percentage(totals.Clicked, totals.Delivered)))
+	}
+
+	for _, group := range groups {
+		for _, dp := range group.points {
+			var rowTotals templateTotals
+			rowTotals.add(dp)
+			addTotalsRow(false, formatTemplateDate(dp.Start, loc, data.Granularity), dp.TemplateID, rowTotals)
+		}
+		if len(group.points) > 1 {
+			if groupBy == "date" {
+				addTotalsRow(true, group.key, "Σ Subtotal", group.totals)
+			} else {
+				addTotalsRow(true, "Σ Subtotal", group.key, group.totals)
+			}
+		}
+	}
+	doc.sections = append(doc.sections, section{title: "Template Analytics", table: table})
+
+	totals := sumTemplatePoints(data.DataPoints)
+	summary := section{
+		title: "Summary",
+		items: []string{
+			fmt.Sprintf("Total Sent: %s", o.count(totals.Sent)),
+			fmt.Sprintf("Total Delivered: %s", o.count(totals.Delivered)),
+			fmt.Sprintf("Total Read: %s (%s)", o.count(totals.Read), o.percent(percentage(totals.Read, totals.Delivered))),
+			fmt.Sprintf("Total Clicked: %s (%s)", o.count(totals.Clicked), o.percent(percentage(totals.Clicked, totals.Delivered))),
+			fmt.Sprintf("Total Cost: %s", o.cost(totals.Cost)),
+		},
+	}
+	if totals.Cost > 0 && totals.Delivered > 0 {
+		summary.items = append(summary.items, fmt.Sprintf("Cost per Delivered: %s", o.costPerUnit(totals.Cost/float64(totals.Delivered))))
+	}
+	if totals.Cost > 0 && totals.Read > 0 {
+		summary.items = append(summary.items, fmt.Sprintf("Cost per Read: %s", o.costPerUnit(totals.Cost/float64(totals.Read))))
+	}
+	if totals.Cost > 0 && totals.Clicked > 0 {
+		summary.items = append(summary.items, fmt.Sprintf("Cost per Click: %s", o.costPerUnit(totals.Cost/float64(totals.Clicked))))
+	}
+	doc.sections = append(doc.sections, summary)
+
+	templateGroups := groups
+	if groupBy == "date" {
+		templateGroups = groupTemplatePoints(data.DataPoints, "template", o.sortBy, loc, data.Granularity)
+	}
+
+	doc.sections = append(doc.sections, section{title: "Read and Clicked per Template", chart: o.templateChart(templateGroups)})
+
+	if len(templateGroups) > 1 {
+		perTemplate := &docTable{
+			headers: []string{"Template ID", "Sent", "Delivered", "Read", "Clicked", "Cost", "Read%", "Click%"},
+			numeric: []bool{false, true, true, true, true, true, true, true},
+		}
+		for _, group := range templateGroups {
+			perTemplate.addRow(false, group.key,
+				o.count(group.totals.Sent), o.count(group.totals.Delivered), o.count(group.totals.Read), o.count(group.totals.Clicked),
+				o.cost(group.totals.Cost),
+				o.percent(percentage(group.totals.Read, group.totals.Delivered)),
+				o.percent(percentage(group.totals.Clicked, group.totals.Delivered)))
+		}
+		doc.sections = append(doc.sections, section{title: "Per-Template Summary", table: perTemplate})
+	}
+
+	if hasCostMetrics(data.DataPoints) {
+		costs := &docTable{
+			headers: costBreakdownHeaders,
+			numeric: []bool{false, true, true, true, true, true},
+		}
+		rows, total, derived := o.costBreakdownRows(templateGroups, data.DataPoints)
+		for _, row := range rows {
+			costs.addRow(false, row...)
+		}
+		if total != nil {
+			costs.addRow(true, total...)
+		}
+
+		breakdown := section{title: fmt.Sprintf("Cost Breakdown (%s)", o.money().Code), table: costs}
+		if derived {
+			breakdown.notes = append(breakdown.notes, "* derived locally from amount spent")
+		}
+		doc.sections = append(doc.sections, breakdown)
+
+		if items := o.otherCostItems(data.DataPoints); len(items) > 0 {
+			doc.sections = append(doc.sections, section{title: "Other Cost Metrics (average per data point)", items: items})
+		}
+	}
+
+	if items := o.clickDetailItems(data.DataPoints); len(items) > 0 {
+		doc.sections = append(doc.sections, section{title: "Click Details", items: items})
+	}
+
+	return doc
+}
+
+// analyticsChart plots sent and delivered per bucket as lines
+func (o *reportOptions) analyticsChart(response *models.AnalyticsResponse, loc *time.Location) *chart {
+	c := &chart{
+		kind: "line",
+		series: []chartSeries{
+			{name: "Sent", color: "#0969da"},
+			{name: "Delivered", color: "#25d366"},
+		},
+		formatValue: o.chartValue,
+	}
+
+	for _, dp := range response.Analytics.DataPoints {
+		date, timeRange := formatTimeRange(dp.Start, dp.End, loc, response.Analytics.Granularity)
+		label := date
+		if response.Analytics.Granularity == "HALF_HOUR" || response.Analytics.Granularity == "HOUR" {
+			label = date + " " + strings.SplitN(timeRange, " ", 2)[0]
+		}
+		c.labels = append(c.labels, label)
+		c.series[0].values = append(c.series[0].values, float64(dp.Sent))
+		c.series[1].values = append(c.series[1].values, float64(dp.Delivered))
+	}
+
+	return c
+}
+
+// templateChart stacks clicked and read-but-not-clicked per template, so each bar is as tall as the reads
+func (o *reportOptions) templateChart(groups []templateGroup) *chart {
+	c := &chart{
+		kind: "stacked-bar",
+		series: []chartSeries{
+			{name: "Clicked", color: "#bf8700"},
+			{name: "Read, not clicked", color: "#8250df"},
+		},
+		formatValue: o.chartValue,
+	}
+
+	for _, group := range groups {
+		c.labels = append(c.labels, group.key)
+		c.series[0].values = append(c.series[0].values, float64(group.totals.Clicked))
+		c.series[1].values = append(c.series[1].values, math.Max(float64(group.totals.Read-group.totals.Clicked), 0))
+	}
+
+	return c
+}
+
+// chartValue formats a chart axis or tooltip value as a count
+func (o *reportOptions) chartValue(v float64) string {
+	return o.count(int(math.Round(v)))
+}
+
+// clickDetailItems totals clicks per button across points, most clicked first
+func (o *reportOptions) clickDetailItems(points []models.TemplateDataPoint) []string {
+	clickSummary := make(map[string]int)
+	for _, dp := range points {
+		for _, clicked := range dp.Clicked {
+			clickSummary[fmt.Sprintf("%s: %s", clicked.Type, clicked.ButtonContent)] += clicked.Count
+		}
+	}
+
+	var items []string
+	for _, action := range sortedCountKeys(clickSummary) {
+		items = append(items, fmt.Sprintf("%s: %s clicks", action, o.count(clickSummary[action])))
+	}
+	return items
+}
+
+// listDocument builds the document for a template list response
+func (o *reportOptions) listDocument(response *models.TemplateListResponse) document {
+	doc := document{
+		title:   "WhatsApp Message Templates",
+		details: []detail{{"Total Templates", o.count(len(response.Data))}},
+	}
+
+	if len(response.Data) == 0 {
+		doc.sections = append(doc.sections, section{notes: []string{"No templates found."}})
+		return doc
+	}
+
+	table := &docTable{
+		headers: []string{"ID", "Name", "Language", "Status", "Category"},
+		numeric: []bool{false, false, false, false, false},
+	}
+	statusCounts := make(map[string]int)
+	categoryCounts := make(map[string]int)
+	languageCounts := make(map[string]int)
+
+	for _, template := range sortedTemplates(response.Data, o.sortBy) {
+		table.addRow(false, template.ID, template.Name, template.Language, formatStatusSimple(template.Status), template.Category)
+		statusCounts[strings.ToUpper(template.Status)]++
+		categoryCounts[strings.ToUpper(template.Category)]++
+		languageCounts[strings.ToUpper(template.Language)]++
+	}
+	doc.sections = append(doc.sections, section{title: "Templates", table: table})
+
+	doc.sections = append(doc.sections,
+		section{title: "Status Breakdown", items: o.countItems(statusCounts)},
+		section{title: "Category Breakdown", items: o.countItems(categoryCounts)},
+		section{title: "Language Breakdown", items: o.countItems(languageCounts)})
+
+	if response.Paging != nil && response.Paging.Cursors != nil && response.Paging.Cursors.After != "" {
+		doc.sections = append(doc.sections, section{
+			title: "Pagination",
+			items: []string{fmt.Sprintf("Next page: -after=\"%s\"", response.Paging.Cursors.After)},
+		})
+	}
+
+	return doc
+}
+
+// countItems formats "key: count" items, largest count first, then by key
+func (o *reportOptions) countItems(counts map[string]int) []string {
+	var items []string
+	for _, key := range sortedCountKeys(counts) {
+		items = append(items, fmt.Sprintf("%s: %s", key, o.count(counts[key])))
+	}
+	return items
+}
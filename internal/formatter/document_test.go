@@ -0,0 +1,140 @@
+package formatter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"wppanalyticscli/internal/models"
+)
+
+func TestMarkdownFormatter_FormatTemplate(t *testing.T) {
+	formatter := NewMarkdownFormatter()
+
+	loc, _ := time.LoadLocation("UTC")
+	result := formatter.FormatTemplate(groupingTestResponse(), loc)
+
+	expectedStrings := []string{
+		"# Template Analytics Report",
+		"- **Granularity:** DAILY",
+		"| Date | Template ID | Sent | Delivered | Read | Clicked | Cost | Click Rate % |",
+		"| --- | --- | ---: | ---: | ---: | ---: | ---: | ---: |",
+		"| **Σ Subtotal** | **1111** | **150** | **130** | **65** | **0** | **$1.50** | **0.0%** |",
+		"## Per-Template Summary",
+		"- Total Sent: 460",
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Formatted output doesn't contain expected string: %s\n%s", expected, result)
+		}
+	}
+}
+
+func TestMarkdownFormatter_FormatListEscapes(t *testing.T) {
+	formatter := NewMarkdownFormatter()
+
+	response := &models.TemplateListResponse{
+		Data: []models.MessageTemplate{
+			{ID: "1", Name: "promo_cartão|v2", Language: "pt_BR", Status: "APPROVED", Category: "MARKETING"},
+		},
+	}
+
+	result := formatter.FormatList(response)
+
+	expected := `| 1 | promo\_cartão\|v2 | pt\_BR | APPROVED | MARKETING |`
+	if !strings.Contains(result, expected) {
+		t.Errorf("Formatted output doesn't contain expected string: %s\n%s", expected, result)
+	}
+}
+
+func TestHTMLFormatter_Format(t *testing.T) {
+	formatter := NewHTMLFormatter()
+
+	response := &models.AnalyticsResponse{ID: "932157148829117"}
+	response.Analytics.Granularity = "DAY"
+	response.Analytics.PhoneNumbers = []string{"551148619349"}
+	response.Analytics.DataPoints = []models.DataPoint{
+		{Start: 1750474800, End: 1750561200, Sent: 523, Delivered: 500},
+	}
+
+	loc, _ := time.LoadLocation("UTC")
+	result := formatter.Format(response, loc)
+
+	expectedStrings := []string{
+		"<!DOCTYPE html>",
+		"<style>",
+		"<h1>WhatsApp Business Account 932157148829117</h1>",
+		"<th class=\"num\">Sent</th>",
+		"<tr class=\"total\"><td>Total</td><td></td><td class=\"num\">523</td>",
+		"<li>Total Delivered: 500</li>",
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Formatted output doesn't contain expected string: %s\n%s", expected, result)
+		}
+	}
+
+	if strings.Contains(result, "<script") || strings.Contains(result, "<link") || strings.Contains(result, "http") {
+		t.Errorf("Expected a self-contained report without external assets\n%s", result)
+	}
+}
+
+func TestHTMLFormatter_Charts(t *testing.T) {
+	loc, _ := time.LoadLocation("UTC")
+
+	result := NewHTMLFormatter().FormatTemplate(groupingTestResponse(), loc)
+
+	expectedStrings := []string{
+		"<h2>Read and Clicked per Template</h2>",
+		"<svg class=\"chart\" viewBox=\"0 0 800 300\" role=\"img\">",
+		"<title>1111 — Read, not clicked: 65</title>",
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Formatted output doesn't contain expected string: %s\n%s", expected, result)
+		}
+	}
+
+	markdown := NewMarkdownFormatter().FormatTemplate(groupingTestResponse(), loc)
+	if strings.Contains(markdown, "<svg") || strings.Contains(markdown, "Read and Clicked per Template") {
+		t.Errorf("Expected charts to be omitted from markdown\n%s", markdown)
+	}
+}
+
+func TestRenderSVGChart_Line(t *testing.T) {
+	c := chart{
+		kind:   "line",
+		labels: []string{"2025-06-20", "2025-06-21"},
+		series: []chartSeries{{name: "Sent", color: "#0969da", values: []float64{100, 180}}},
+	}
+
+	result := renderSVGChart(c)
+
+	// The axis tops out at 200 and the plot spans y=36..252
+	expected := `<polyline points="247.5,144.0 602.5,57.6" fill="none" stroke="#0969da" stroke-width="2"/>`
+	if !strings.Contains(result, expected) {
+		t.Errorf("Expected line %s\n%s", expected, result)
+	}
+}
+
+func TestNiceCeiling(t *testing.T) {
+	tests := []struct {
+		input    float64
+		expected float64
+	}{
+		{0, 1},
+		{7, 10},
+		{180, 200},
+		{230, 250},
+		{4100, 5000},
+	}
+
+	for _, tt := range tests {
+		if result := niceCeiling(tt.input); result != tt.expected {
+			t.Errorf("niceCeiling(%v): expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package formatter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"wppanalyticscli/internal/models"
+)
+
+// FunnelFormatter formats template analytics as a sent → delivered → read → clicked funnel
+type FunnelFormatter struct {
+	reportOptions
+}
+
+// NewFunnelFormatter creates a new funnel formatter
+func NewFunnelFormatter() *FunnelFormatter {
+	return &FunnelFormatter{}
+}
+
+// templateFunnel holds the funnel totals and per-button clicks of one template
+type templateFunnel struct {
+	templateID string
+	totals     templateTotals
+	buttons    []models.ClickedAction
+}
+
+// conversion returns the end-to-end conversion from sent to clicked
+func (t templateFunnel) conversion() float64 {
+	return percentage(t.totals.Clicked, t.totals.Sent)
+}
+
+// FormatFunnel formats one funnel per template, ranked by end-to-end conversion
+func (f *FunnelFormatter) FormatFunnel(response *models.TemplateAnalyticsResponse, loc *time.Location) string {
+	var output strings.Builder
+
+	points := templateDataPoints(response)
+
+	output.WriteString("🏁 Template Funnel Report\n")
+	f.writeWindow(&output, loc)
+	output.WriteString(fmt.Sprintf("🌎 Timezone: %s\n\n", loc.String()))
+
+	if len(points) == 0 {
+		output.WriteString("❌ No template analytics data found.\n")
+		return output.String()
+	}
+
+	funnels := buildFunnels(points)
+
+	table := newTextTable(
+		tableColumn{header: "#", width: 3, align: alignRight},
+		tableColumn{header: "Template ID", width: 15, flex: true, min: 10},
+		tableColumn{header: "Sent", width: 8, align: alignRight},
+		tableColumn{header: "Delivered", width: 9, align: alignRight},
+		tableColumn{header: "Deliv%", width: 7, align: alignRight},
+		tableColumn{header: "Read", width: 8, align: alignRight},
+		tableColumn{header: "Read%", width: 7, align: alignRight},
+		tableColumn{header: "Clicked", width: 8, align: alignRight},
+		tableColumn{header: "Click%", width: 7, align: alignRight},
+		tableColumn{header: "Overall%", width: 8, align: alignRight},
+	)
+
+	for i, funnel := range funnels {
+		totals := funnel.totals
+		table.addRow(strconv.Itoa(i+1), funnel.templateID,
+			f.count(totals.Sent),
+			f.count(totals.Delivered), f.percent(percentage(totals.Delivered, totals.Sent)),
+			f.count(totals.Read), f.percent(percentage(totals.Read, totals.Delivered)),
+			f.count(totals.Clicked), f.percent(percentage(totals.Clicked, totals.Read)),
+			f.percent(funnel.conversion()))
+	}
+
+	f.renderTable(&output, table)
+
+	for i, funnel := range funnels {
+		totals := funnel.totals
+		output.WriteString(fmt.Sprintf("\n%d. 🧩 Template %s\n", i+1, funnel.templateID))
+		output.WriteString(fmt.Sprintf("   📤 Sent:      %8s\n", f.count(totals.Sent)))
+		output.WriteString(fmt.Sprintf("   📥 Delivered: %8s  %6s of sent       ▼ %s dropped\n",
+			f.count(totals.Delivered), f.percent(percentage(totals.Delivered, totals.Sent)), f.count(dropOff(totals.Sent, totals.Delivered))))
+		output.WriteString(fmt.Sprintf("   👀 Read:      %8s  %6s of delivered  ▼ %s dropped\n",
+			f.count(totals.Read), f.percent(percentage(totals.Read, totals.Delivered)), f.count(dropOff(totals.Delivered, totals.Read))))
+		output.WriteString(fmt.Sprintf("   👆 Clicked:   %8s  %6s of read       ▼ %s dropped\n",
+			f.count(totals.Clicked), f.percent(percentage(totals.Clicked, totals.Read)), f.count(dropOff(totals.Read, totals.Clicked))))
+
+		if len(funnel.buttons) > 0 {
+			output.WriteString("   🔘 Button Clicks:\n")
+			for _, button := range funnel.buttons {
+				output.WriteString(fmt.Sprintf("      • %s: %s — %s clicks (%s)\n",
+					button.Type, button.ButtonContent, f.count(button.Count), f.percent(percentage(button.Count, totals.Clicked))))
+			}
+		}
+	}
+
+	return output.String()
+}
+
+// buildFunnels totals points per template and ranks them by end-to-end conversion,
+// then by volume sent and template ID. Buttons are ordered by clicks, then by type
+// and content.
+func buildFunnels(points []models.TemplateDataPoint) []templateFunnel {
+	var funnels []templateFunnel
+	index := make(map[string]int)
+
+	for _, dp := range points {
+		i, ok := index[dp.TemplateID]
+		if !ok {
+			index[dp.TemplateID] = len(funnels)
+			funnels = append(funnels, templateFunnel{templateID: dp.TemplateID})
+			i = len(funnels) - 1
+		}
+
+		funnel := &funnels[i]
+		funnel.totals.add(dp)
+		for _, clicked := range dp.Clicked {
+			found := false
+			for j := range funnel.buttons {
+				if funnel.buttons[j].Type == clicked.Type && funnel.buttons[j].ButtonContent == clicked.ButtonContent {
+					funnel.buttons[j].Count += clicked.Count
+					found = true
+					break
+				}
+			}
+			if !found {
+				funnel.buttons = append(funnel.buttons, clicked)
+			}
+		}
+	}
+
+	for i := range funnels {
+		buttons := funnels[i].buttons
+		sort.SliceStable(buttons, func(a, b int) bool {
+			if buttons[a].Count != buttons[b].Count {
+				return buttons[a].Count > buttons[b].Count
+			}
+			if buttons[a].Type != buttons[b].Type {
+				return buttons[a].Type < buttons[b].Type
+			}
+			return buttons[a].ButtonContent < buttons[b].ButtonContent
+		})
+	}
+
+	sort.SliceStable(funnels, func(a, b int) bool {
+		if funnels[a].conversion() != funnels[b].conversion() {
+			return funnels[a].conversion() > funnels[b].conversion()
+		}
+		if funnels[a].totals.Sent != funnels[b].totals.Sent {
+			return funnels[a].totals.Sent > funnels[b].totals.Sent
+		}
+		return funnels[a].templateID < funnels[b].templateID
+	})
+
+	return funnels
+}
+
+// dropOff returns how many people did not reach the next funnel step
+func dropOff(from, to int) int {
+	if to >= from {
+		return 0
+	}
+	return from - to
+}
@@ -0,0 +1,95 @@
+package formatter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"wppanalyticscli/internal/models"
+)
+
+func TestFunnelFormatter_FormatFunnel(t *testing.T) {
+	formatter := NewFunnelFormatter()
+
+	response := &models.TemplateAnalyticsResponse{
+		Data: []models.TemplateAnalyticsData{{
+			Granularity: "DAILY",
+			DataPoints: []models.TemplateDataPoint{
+				{
+					TemplateID: "1111", Sent: 1000, Delivered: 900, Read: 450,
+					Clicked: []models.ClickedAction{{Type: "url_button", ButtonContent: "Buy", Count: 9}},
+				},
+				{
+					TemplateID: "2222", Sent: 200, Delivered: 200, Read: 100,
+					Clicked: []models.ClickedAction{
+						{Type: "quick_reply_button", ButtonContent: "Stop", Count: 10},
+						{Type: "quick_reply_button", ButtonContent: "Quero negociar", Count: 30},
+					},
+				},
+				{
+					TemplateID: "1111", Sent: 0, Delivered: 50, Read: 50,
+					Clicked: []models.ClickedAction{{Type: "url_button", ButtonContent: "Buy", Count: 1}},
+				},
+			},
+		}},
+	}
+
+	loc, _ := time.LoadLocation("UTC")
+	result := formatter.FormatFunnel(response, loc)
+
+	expectedStrings := []string{
+		"🏁 Template Funnel Report",
+		"1. 🧩 Template 2222",
+		"2. 🧩 Template 1111",
+		"📥 Delivered:      950   95.0% of sent       ▼ 50 dropped",
+		"👀 Read:           500   52.6% of delivered  ▼ 450 dropped",
+		"• quick_reply_button: Quero negociar — 30 clicks (75.0%)",
+		"• quick_reply_button: Stop — 10 clicks (25.0%)",
+		"• url_button: Buy — 10 clicks (100.0%)",
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Formatted output doesn't contain expected string: %s\n%s", expected, result)
+		}
+	}
+
+	// Buttons are listed by clicks, most clicked first
+	if strings.Index(result, "Quero negociar") > strings.Index(result, "Stop —") {
+		t.Errorf("Expected button clicks ranked by count")
+	}
+}
+
+func TestBuildFunnels_ButtonTies(t *testing.T) {
+	clicks := []models.ClickedAction{
+		{Type: "url_button", ButtonContent: "Buy", Count: 5},
+		{Type: "quick_reply_button", ButtonContent: "Stop", Count: 5},
+		{Type: "quick_reply_button", ButtonContent: "More", Count: 5},
+	}
+	reversed := []models.ClickedAction{clicks[2], clicks[1], clicks[0]}
+
+	for _, order := range [][]models.ClickedAction{clicks, reversed} {
+		funnels := buildFunnels([]models.TemplateDataPoint{{TemplateID: "1111", Sent: 10, Clicked: order}})
+
+		var got []string
+		for _, button := range funnels[0].buttons {
+			got = append(got, button.ButtonContent)
+		}
+		if strings.Join(got, ",") != "More,Stop,Buy" {
+			t.Errorf("Expected tied buttons ordered by type and content, got %v", got)
+		}
+	}
+}
+
+func TestFunnelFormatter_FormatFunnelEmptyData(t *testing.T) {
+	formatter := NewFunnelFormatter()
+
+	response := &models.TemplateAnalyticsResponse{}
+
+	loc, _ := time.LoadLocation("UTC")
+	result := formatter.FormatFunnel(response, loc)
+
+	if !strings.Contains(result, "❌ No template analytics data found.") {
+		t.Errorf("Expected 'No template analytics data found' message for empty data")
+	}
+}
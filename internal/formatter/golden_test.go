@@ -0,0 +1,95 @@
+package formatter
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"wppanalyticscli/internal/models"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// assertGolden compares output with testdata/<name>.golden, rewriting it with -update
+func assertGolden(t *testing.T, name, output string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+
+	if *update {
+		if err := os.WriteFile(path, []byte(output), 0644); err != nil {
+			t.Fatalf("Failed to update %s: %v", path, err)
+		}
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", path, err)
+	}
+	if string(expected) != output {
+		t.Errorf("Output doesn't match %s (run go test -update to accept)\nExpected:\n%s\nGot:\n%s", path, expected, output)
+	}
+}
+
+func goldenListResponse() *models.TemplateListResponse {
+	return &models.TemplateListResponse{
+		Data: []models.MessageTemplate{
+			{ID: "5", Name: "welcome", Language: "en_US", Status: "APPROVED", Category: "UTILITY"},
+			{ID: "3", Name: "promo_summer", Language: "pt_BR", Status: "REJECTED", Category: "MARKETING"},
+			{ID: "4", Name: "order_update", Language: "pt_BR", Status: "APPROVED", Category: "UTILITY"},
+			{ID: "1", Name: "black_friday", Language: "es_MX", Status: "PAUSED", Category: "MARKETING"},
+			{ID: "2", Name: "otp", Language: "en_US", Status: "PENDING", Category: "AUTHENTICATION"},
+		},
+	}
+}
+
+func goldenClickResponse() *models.TemplateAnalyticsResponse {
+	response := groupingTestResponse()
+	points := response.Data[0].DataPoints
+	points[0].Clicked = []models.ClickedAction{
+		{Type: "quick_reply_button", ButtonContent: "Stop", Count: 3},
+		{Type: "url_button", ButtonContent: "Shop now", Count: 7},
+	}
+	points[1].Clicked = []models.ClickedAction{
+		{Type: "url_button", ButtonContent: "Track order", Count: 7},
+		{Type: "quick_reply_button", ButtonContent: "Stop", Count: 1},
+	}
+	return response
+}
+
+func TestGolden_ListFormatter(t *testing.T) {
+	assertGolden(t, "list", NewListFormatter().FormatList(goldenListResponse()))
+}
+
+func TestGolden_ListFormatterSortStatus(t *testing.T) {
+	formatter := NewListFormatter()
+	formatter.SetSort("status")
+	assertGolden(t, "list_sort_status", formatter.FormatList(goldenListResponse()))
+}
+
+func TestGolden_MarkdownList(t *testing.T) {
+	assertGolden(t, "list_markdown", NewMarkdownFormatter().FormatList(goldenListResponse()))
+}
+
+func TestGolden_TemplateClickDetails(t *testing.T) {
+	loc, _ := time.LoadLocation("UTC")
+	assertGolden(t, "template_clicks", NewTemplateFormatter().FormatTemplate(goldenClickResponse(), loc))
+}
+
+func TestGolden_TableFormatterSortSent(t *testing.T) {
+	formatter := NewTableFormatter()
+	formatter.SetSort("sent")
+	loc, _ := time.LoadLocation("UTC")
+
+	response := &models.AnalyticsResponse{ID: "932157148829117"}
+	response.Analytics.Granularity = "DAY"
+	response.Analytics.PhoneNumbers = []string{"551148619349"}
+	response.Analytics.DataPoints = []models.DataPoint{
+		{Start: 1750377600, End: 1750464000, Sent: 120, Delivered: 118},
+		{Start: 1750464000, End: 1750550400, Sent: 523, Delivered: 500},
+		{Start: 1750550400, End: 1750636800, Sent: 120, Delivered: 90},
+	}
+
+	assertGolden(t, "analytics_sort_sent", formatter.Format(response, loc))
+}
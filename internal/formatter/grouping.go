@@ -0,0 +1,112 @@
+package formatter
+
+import (
+	"sort"
+	"time"
+
+	"wppanalyticscli/internal/models"
+)
+
+// templateGroup is a set of template data points sharing a template ID or a date
+type templateGroup struct {
+	key    string
+	start  int64
+	points []models.TemplateDataPoint
+	totals templateTotals
+}
+
+// groupTemplatePoints groups points by "template" or "date" and orders groups and
+// their rows by sortBy. Without a sort key, template groups keep the API order and
+// date groups are chronological.
+func groupTemplatePoints(points []models.TemplateDataPoint, groupBy, sortBy string, loc *time.Location, granularity string) []templateGroup {
+	var groups []templateGroup
+	index := make(map[string]int)
+
+	for _, dp := range points {
+		key := dp.TemplateID
+		if groupBy == "date" {
+			key = formatTemplateDate(dp.Start, loc, granularity)
+		}
+
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(groups)
+			groups = append(groups, templateGroup{key: key, start: dp.Start})
+			i = len(groups) - 1
+		}
+
+		group := &groups[i]
+		group.points = append(group.points, dp)
+		group.totals.add(dp)
+		if dp.Start < group.start {
+			group.start = dp.Start
+		}
+	}
+
+	for i := range groups {
+		sortTemplatePoints(groups[i].points, sortBy)
+	}
+
+	sort.SliceStable(groups, func(a, b int) bool {
+		switch sortBy {
+		case "", "date":
+			if groupBy == "date" || sortBy == "date" {
+				return groups[a].start < groups[b].start
+			}
+			return false
+		case "template":
+			return groups[a].key < groups[b].key
+		default:
+			metricA, metricB := templateMetric(groups[a].totals, sortBy), templateMetric(groups[b].totals, sortBy)
+			if metricA != metricB {
+				return metricA > metricB
+			}
+			return groups[a].key < groups[b].key
+		}
+	})
+
+	return groups
+}
+
+// sortTemplatePoints orders rows by sortBy: chronologically by default, by template
+// ID for "template", and by descending value for metrics
+func sortTemplatePoints(points []models.TemplateDataPoint, sortBy string) {
+	sort.SliceStable(points, func(a, b int) bool {
+		switch sortBy {
+		case "", "date":
+			return points[a].Start < points[b].Start
+		case "template":
+			if points[a].TemplateID != points[b].TemplateID {
+				return points[a].TemplateID < points[b].TemplateID
+			}
+			return points[a].Start < points[b].Start
+		default:
+			var totalsA, totalsB templateTotals
+			totalsA.add(points[a])
+			totalsB.add(points[b])
+			metricA, metricB := templateMetric(totalsA, sortBy), templateMetric(totalsB, sortBy)
+			if metricA != metricB {
+				return metricA > metricB
+			}
+			return points[a].Start < points[b].Start
+		}
+	})
+}
+
+// templateMetric returns the value of a sortable metric from template totals
+func templateMetric(totals templateTotals, metric string) float64 {
+	switch metric {
+	case "sent":
+		return float64(totals.Sent)
+	case "delivered":
+		return float64(totals.Delivered)
+	case "read":
+		return float64(totals.Read)
+	case "clicks":
+		return float64(totals.Clicked)
+	case "cost":
+		return totals.Cost
+	default:
+		return 0
+	}
+}
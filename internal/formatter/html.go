@@ -0,0 +1,105 @@
+package formatter
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// NewHTMLFormatter creates a formatter producing a standalone HTML report
+func NewHTMLFormatter() *DocumentFormatter {
+	return &DocumentFormatter{render: renderHTML}
+}
+
+// htmlStyle is the inline stylesheet, so the report has no external assets
+const htmlStyle = `body { font-family: -apple-system, "Segoe UI", Roboto, Helvetica, Arial, sans-serif; color: #1f2328; margin: 2rem auto; max-width: 1100px; padding: 0 1rem; }
+h1 { font-size: 1.6rem; border-bottom: 2px solid #25d366; padding-bottom: .4rem; }
+h2 { font-size: 1.2rem; margin-top: 2rem; }
+dl.details { display: grid; grid-template-columns: max-content auto; gap: .2rem 1rem; }
+dl.details dt { font-weight: 600; }
+dl.details dd { margin: 0; }
+table { border-collapse: collapse; width: 100%; font-size: .9rem; }
+th, td { border: 1px solid #d0d7de; padding: .35rem .6rem; text-align: left; }
+th { background: #f6f8fa; }
+td.num, th.num { text-align: right; font-variant-numeric: tabular-nums; }
+tr.total td { font-weight: 600; background: #f6f8fa; }
+p.note { color: #59636e; font-size: .85rem; }
+svg.chart { width: 100%; height: auto; max-width: 800px; font-family: inherit; }
+`
+
+// renderHTML renders a document as a self-contained HTML page
+func renderHTML(doc document) string {
+	var output strings.Builder
+
+	output.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n")
+	output.WriteString(fmt.Sprintf("<title>%s</title>\n", html.EscapeString(doc.title)))
+	output.WriteString("<style>\n" + htmlStyle + "</style>\n</head>\n<body>\n")
+	output.WriteString(fmt.Sprintf("<h1>%s</h1>\n", html.EscapeString(doc.title)))
+
+	if len(doc.details) > 0 {
+		output.WriteString("<dl class=\"details\">\n")
+		for _, d := range doc.details {
+			output.WriteString(fmt.Sprintf("<dt>%s</dt><dd>%s</dd>\n", html.EscapeString(d.label), html.EscapeString(d.value)))
+		}
+		output.WriteString("</dl>\n")
+	}
+
+	for _, s := range doc.sections {
+		if s.title != "" {
+			output.WriteString(fmt.Sprintf("<h2>%s</h2>\n", html.EscapeString(s.title)))
+		}
+
+		if s.chart != nil {
+			output.WriteString(renderSVGChart(*s.chart))
+		}
+
+		if s.table != nil {
+			writeHTMLTable(&output, s.table)
+		}
+
+		if len(s.items) > 0 {
+			output.WriteString("<ul>\n")
+			for _, item := range s.items {
+				output.WriteString(fmt.Sprintf("<li>%s</li>\n", html.EscapeString(item)))
+			}
+			output.WriteString("</ul>\n")
+		}
+
+		for _, note := range s.notes {
+			output.WriteString(fmt.Sprintf("<p class=\"note\">%s</p>\n", html.EscapeString(note)))
+		}
+	}
+
+	output.WriteString("</body>\n</html>\n")
+	return output.String()
+}
+
+// writeHTMLTable writes a table with numeric columns right-aligned and total rows emphasized
+func writeHTMLTable(output *strings.Builder, table *docTable) {
+	class := func(i int) string {
+		if i < len(table.numeric) && table.numeric[i] {
+			return " class=\"num\""
+		}
+		return ""
+	}
+
+	output.WriteString("<table>\n<thead><tr>")
+	for i, header := range table.headers {
+		output.WriteString(fmt.Sprintf("<th%s>%s</th>", class(i), html.EscapeString(header)))
+	}
+	output.WriteString("</tr></thead>\n<tbody>\n")
+
+	for _, row := range table.rows {
+		if row.total {
+			output.WriteString("<tr class=\"total\">")
+		} else {
+			output.WriteString("<tr>")
+		}
+		for i, cell := range row.cells {
+			output.WriteString(fmt.Sprintf("<td%s>%s</td>", class(i), html.EscapeString(cell)))
+		}
+		output.WriteString("</tr>\n")
+	}
+
+	output.WriteString("</tbody>\n</table>\n")
+}
@@ -8,7 +8,9 @@ import (
 )
 
 // ListFormatter implements the formatter for template lists
-type ListFormatter struct{}
+type ListFormatter struct {
+	reportOptions
+}
 
 // NewListFormatter creates a new list formatter
 func NewListFormatter() *ListFormatter {
@@ -20,30 +22,27 @@ func (f *ListFormatter) FormatList(response *models.TemplateListResponse) string
 	var output strings.Builder
 	
 	output.WriteString(fmt.Sprintf("📋 WhatsApp Message Templates\n"))
-	output.WriteString(fmt.Sprintf("📊 Total Templates: %d\n\n", len(response.Data)))
+	output.WriteString(fmt.Sprintf("📊 Total Templates: %s\n\n", f.count(len(response.Data))))
 	
 	if len(response.Data) == 0 {
 		output.WriteString("❌ No templates found.\n")
 		return output.String()
 	}
 	
-	// Table header
-	output.WriteString("╭──────────────────────┬─────────────────────────────────┬──────────┬─────────────┬─────────────╮\n")
-	output.WriteString("│         ID           │              Name               │ Language │   Status    │  Category   │\n")
-	output.WriteString("├──────────────────────┼─────────────────────────────────┼──────────┼─────────────┼─────────────┤\n")
+	table := newTextTable(
+		tableColumn{header: "ID", width: 20, flex: true, min: 10},
+		tableColumn{header: "Name", width: 31, flex: true, min: 12},
+		tableColumn{header: "Language", width: 8, flex: true, min: 5},
+		tableColumn{header: "Status", width: 11, flex: true},
+		tableColumn{header: "Category", width: 11, flex: true},
+	)
+	table.centerHeaders = true
 	
-	for _, template := range response.Data {
-		templateID := truncateString(template.ID, 20)
-		templateName := truncateString(template.Name, 31)
-		language := truncateString(template.Language, 8)
-		status := formatStatusSimple(template.Status)
-		category := truncateString(template.Category, 11)
-		
-		output.WriteString(fmt.Sprintf("│ %-20s │ %-31s │ %-8s │ %-11s │ %-11s │\n",
-			templateID, templateName, language, status, category))
+	for _, template := range sortedTemplates(response.Data, f.sortBy) {
+		table.addRow(template.ID, template.Name, template.Language, f.paint(formatStatusSimple(template.Status), statusColor(template.Status)), template.Category)
 	}
 	
-	output.WriteString("╰──────────────────────┴─────────────────────────────────┴──────────┴─────────────┴─────────────╯\n")
+	f.renderTable(&output, table)
 	
 	// Status summary
 	statusCounts := make(map[string]int)
@@ -60,24 +59,24 @@ func (f *ListFormatter) FormatList(response *models.TemplateListResponse) string
 	
 	// Status breakdown
 	output.WriteString(fmt.Sprintf("   📊 Status Breakdown:\n"))
-	for status, count := range statusCounts {
+	for _, status := range sortedCountKeys(statusCounts) {
 		emoji := getStatusEmoji(status)
-		output.WriteString(fmt.Sprintf("      %s %s: %d\n", emoji, strings.ToUpper(status), count))
+		output.WriteString(fmt.Sprintf("      %s %s: %s\n", emoji, strings.ToUpper(status), f.count(statusCounts[status])))
 	}
 	
 	// Category breakdown
 	if len(categoryCounts) > 0 {
 		output.WriteString(fmt.Sprintf("   🏷️  Category Breakdown:\n"))
-		for category, count := range categoryCounts {
-			output.WriteString(fmt.Sprintf("      • %s: %d\n", strings.ToUpper(category), count))
+		for _, category := range sortedCountKeys(categoryCounts) {
+			output.WriteString(fmt.Sprintf("      • %s: %s\n", strings.ToUpper(category), f.count(categoryCounts[category])))
 		}
 	}
 	
 	// Language breakdown
 	if len(languageCounts) > 0 {
 		output.WriteString(fmt.Sprintf("   🌐 Language Breakdown:\n"))
-		for language, count := range languageCounts {
-			output.WriteString(fmt.Sprintf("      • %s: %d\n", strings.ToUpper(language), count))
+		for _, language := range sortedCountKeys(languageCounts) {
+			output.WriteString(fmt.Sprintf("      • %s: %s\n", strings.ToUpper(language), f.count(languageCounts[language])))
 		}
 	}
 	
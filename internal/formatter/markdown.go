@@ -0,0 +1,98 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NewMarkdownFormatter creates a formatter producing GitHub-flavored markdown
+func NewMarkdownFormatter() *DocumentFormatter {
+	return &DocumentFormatter{render: renderMarkdown}
+}
+
+// renderMarkdown renders a document with GitHub-flavored markdown tables
+func renderMarkdown(doc document) string {
+	var output strings.Builder
+
+	output.WriteString(fmt.Sprintf("# %s\n\n", escapeMarkdown(doc.title)))
+
+	if len(doc.details) > 0 {
+		for _, d := range doc.details {
+			output.WriteString(fmt.Sprintf("- **%s:** %s\n", d.label, escapeMarkdown(d.value)))
+		}
+		output.WriteString("\n")
+	}
+
+	for _, s := range doc.sections {
+		// Charts are only drawn in HTML
+		if s.table == nil && len(s.items) == 0 && len(s.notes) == 0 {
+			continue
+		}
+
+		if s.title != "" {
+			output.WriteString(fmt.Sprintf("## %s\n\n", escapeMarkdown(s.title)))
+		}
+
+		if s.table != nil {
+			writeMarkdownTable(&output, s.table)
+			output.WriteString("\n")
+		}
+
+		if len(s.items) > 0 {
+			for _, item := range s.items {
+				output.WriteString(fmt.Sprintf("- %s\n", escapeMarkdown(item)))
+			}
+			output.WriteString("\n")
+		}
+
+		for _, note := range s.notes {
+			output.WriteString(fmt.Sprintf("_%s_\n\n", escapeMarkdown(note)))
+		}
+	}
+
+	return output.String()
+}
+
+// writeMarkdownTable writes a table with numeric columns right-aligned and total rows in bold
+func writeMarkdownTable(output *strings.Builder, table *docTable) {
+	output.WriteString("|")
+	for _, header := range table.headers {
+		output.WriteString(fmt.Sprintf(" %s |", escapeMarkdown(header)))
+	}
+	output.WriteString("\n|")
+	for i := range table.headers {
+		if i < len(table.numeric) && table.numeric[i] {
+			output.WriteString(" ---: |")
+		} else {
+			output.WriteString(" --- |")
+		}
+	}
+	output.WriteString("\n")
+
+	for _, row := range table.rows {
+		output.WriteString("|")
+		for _, cell := range row.cells {
+			cell = escapeMarkdown(cell)
+			if row.total && cell != "" {
+				cell = "**" + cell + "**"
+			}
+			output.WriteString(fmt.Sprintf(" %s |", cell))
+		}
+		output.WriteString("\n")
+	}
+}
+
+// markdownEscaper escapes characters that would break tables or add formatting
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"|", `\|`,
+	"*", `\*`,
+	"_", `\_`,
+	"`", "\\`",
+	"\n", " ",
+)
+
+// escapeMarkdown escapes text for use in markdown tables and lists
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
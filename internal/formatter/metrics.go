@@ -0,0 +1,57 @@
+package formatter
+
+import "wppanalyticscli/internal/models"
+
+// templateTotals holds summed template analytics metrics
+type templateTotals struct {
+	Sent      int
+	Delivered int
+	Read      int
+	Clicked   int
+	Cost      float64
+}
+
+// add accumulates a data point into the totals
+func (t *templateTotals) add(dp models.TemplateDataPoint) {
+	t.Sent += dp.Sent
+	t.Delivered += dp.Delivered
+	t.Read += dp.Read
+	t.Clicked += pointClicks(dp)
+	t.Cost += pointCost(dp)
+}
+
+// sumTemplatePoints totals a slice of template data points
+func sumTemplatePoints(points []models.TemplateDataPoint) templateTotals {
+	var totals templateTotals
+	for _, dp := range points {
+		totals.add(dp)
+	}
+	return totals
+}
+
+// pointClicks returns the total clicks across all buttons of a data point
+func pointClicks(dp models.TemplateDataPoint) int {
+	clicks := 0
+	for _, clicked := range dp.Clicked {
+		clicks += clicked.Count
+	}
+	return clicks
+}
+
+// pointCost returns the amount spent for a data point
+func pointCost(dp models.TemplateDataPoint) float64 {
+	for _, costMetric := range dp.Cost {
+		if costMetric.Type == "amount_spent" {
+			return costMetric.Value
+		}
+	}
+	return 0
+}
+
+// percentage returns part as a percentage of whole, or 0 when whole is 0
+func percentage(part, whole int) float64 {
+	if whole <= 0 {
+		return 0
+	}
+	return (float64(part) / float64(whole)) * 100
+}
@@ -0,0 +1,155 @@
+package formatter
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// NumberFormat controls how counts, percentages and amounts are displayed
+type NumberFormat struct {
+	Short     bool   // abbreviate counts as 1.2K and 3.4M
+	Thousands string // thousands separator in exact counts and amounts
+	Decimal   string // decimal separator
+}
+
+// numberLocales lists the separators of the supported locales
+var numberLocales = map[string]NumberFormat{
+	"en-US": {Thousands: ",", Decimal: "."},
+	"en-GB": {Thousands: ",", Decimal: "."},
+	"en-IN": {Thousands: ",", Decimal: "."},
+	"es-MX": {Thousands: ",", Decimal: "."},
+	"ja-JP": {Thousands: ",", Decimal: "."},
+	"pt-BR": {Thousands: ".", Decimal: ","},
+	"pt-PT": {Thousands: " ", Decimal: ","},
+	"es-ES": {Thousands: ".", Decimal: ","},
+	"es-AR": {Thousands: ".", Decimal: ","},
+	"de-DE": {Thousands: ".", Decimal: ","},
+	"it-IT": {Thousands: ".", Decimal: ","},
+	"id-ID": {Thousands: ".", Decimal: ","},
+	"fr-FR": {Thousands: " ", Decimal: ","},
+}
+
+// NewNumberFormat returns the number format for a style ("short" or "exact")
+// and a locale such as pt-BR; empty values mean short and en-US
+func NewNumberFormat(style, locale string) (NumberFormat, error) {
+	if locale == "" {
+		locale = "en-US"
+	}
+
+	format, ok := lookupLocale(locale)
+	if !ok {
+		return NumberFormat{}, fmt.Errorf("unsupported locale '%s', use one of: %s", locale, strings.Join(SupportedLocales(), ", "))
+	}
+
+	switch style {
+	case "", "short":
+		format.Short = true
+	case "exact":
+		format.Short = false
+	default:
+		return NumberFormat{}, fmt.Errorf("invalid number style '%s', use exact or short", style)
+	}
+
+	return format, nil
+}
+
+// SupportedLocales returns the locale tags accepted by NewNumberFormat
+func SupportedLocales() []string {
+	var tags []string
+	for tag := range numberLocales {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// lookupLocale finds a locale ignoring case and accepting "_" as separator
+func lookupLocale(locale string) (NumberFormat, bool) {
+	locale = strings.ReplaceAll(locale, "_", "-")
+	for tag, format := range numberLocales {
+		if strings.EqualFold(tag, locale) {
+			return format, true
+		}
+	}
+	return NumberFormat{}, false
+}
+
+// defaultNumbers is the format used when none was set: short counts and
+// ungrouped amounts with a "." decimal separator
+var defaultNumbers = NumberFormat{Short: true, Decimal: "."}
+
+// Count formats a count, abbreviated with K/M suffixes in short mode
+func (nf NumberFormat) Count(n int) string {
+	if n < 0 {
+		return "-" + nf.Count(-n)
+	}
+	if nf.Short {
+		if n >= 1000000 {
+			return nf.Fixed(float64(n)/1000000, 1) + "M"
+		} else if n >= 1000 {
+			return nf.Fixed(float64(n)/1000, 1) + "K"
+		}
+		return strconv.Itoa(n)
+	}
+	return nf.group(strconv.Itoa(n))
+}
+
+// Fixed formats a value with a fixed number of decimals, without grouping
+func (nf NumberFormat) Fixed(value float64, decimals int) string {
+	return strings.Replace(fmt.Sprintf("%.*f", decimals, value), ".", nf.decimal(), 1)
+}
+
+// Amount formats a non-negative value with grouped thousands and fixed decimals
+func (nf NumberFormat) Amount(value float64, decimals int) string {
+	text := fmt.Sprintf("%.*f", decimals, math.Abs(value))
+	whole, fraction, hasFraction := strings.Cut(text, ".")
+	whole = nf.group(whole)
+	if !hasFraction {
+		return whole
+	}
+	return whole + nf.decimal() + fraction
+}
+
+// Percent formats a percentage with one decimal, e.g. 12.5%
+func (nf NumberFormat) Percent(value float64) string {
+	return nf.Fixed(value, 1) + "%"
+}
+
+// SignedPercent formats a percentage change with an explicit sign, e.g. +12.5%
+func (nf NumberFormat) SignedPercent(value float64) string {
+	if value < 0 {
+		return "-" + nf.Percent(-value)
+	}
+	return "+" + nf.Percent(value)
+}
+
+// group inserts the thousands separator into a string of digits
+func (nf NumberFormat) group(digits string) string {
+	if nf.Thousands == "" || len(digits) <= 3 {
+		return digits
+	}
+
+	var grouped strings.Builder
+	head := len(digits) % 3
+	if head > 0 {
+		grouped.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if grouped.Len() > 0 {
+			grouped.WriteString(nf.Thousands)
+		}
+		grouped.WriteString(digits[i : i+3])
+	}
+	return grouped.String()
+}
+
+// decimal returns the decimal separator, defaulting to "."
+func (nf NumberFormat) decimal() string {
+	if nf.Decimal == "" {
+		return "."
+	}
+	return nf.Decimal
+}
@@ -0,0 +1,55 @@
+package formatter
+
+import "testing"
+
+func TestNumberFormat(t *testing.T) {
+	tests := []struct {
+		style    string
+		locale   string
+		count    int
+		amount   float64
+		percent  float64
+		expCount string
+		expCost  string
+		expPct   string
+	}{
+		{"short", "", 1234, 1234.56, 12.34, "1.2K", "$1,234.56", "12.3%"},
+		{"exact", "en-US", 1234567, 1234.56, 12.34, "1,234,567", "$1,234.56", "12.3%"},
+		{"exact", "pt-BR", 1234, 1234.56, 12.34, "1.234", "R$ 1.234,56", "12,3%"},
+		{"short", "pt_br", 1500, 6.22, 50, "1,5K", "R$ 6,22", "50,0%"},
+		{"exact", "fr-FR", 999, 1234567.891, 0, "999", "€1 234 567,89", "0,0%"},
+	}
+
+	currencies := map[string]string{"pt-BR": "BRL", "pt_br": "BRL", "fr-FR": "EUR"}
+
+	for _, tt := range tests {
+		t.Run(tt.style+"/"+tt.locale, func(t *testing.T) {
+			nf, err := NewNumberFormat(tt.style, tt.locale)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if result := nf.Count(tt.count); result != tt.expCount {
+				t.Errorf("Expected count '%s', got '%s'", tt.expCount, result)
+			}
+
+			if result := LookupCurrency(currencies[tt.locale]).FormatIn(nf, tt.amount); result != tt.expCost {
+				t.Errorf("Expected cost '%s', got '%s'", tt.expCost, result)
+			}
+
+			if result := nf.Percent(tt.percent); result != tt.expPct {
+				t.Errorf("Expected percent '%s', got '%s'", tt.expPct, result)
+			}
+		})
+	}
+}
+
+func TestNewNumberFormat_Invalid(t *testing.T) {
+	if _, err := NewNumberFormat("rounded", "en-US"); err == nil {
+		t.Errorf("Expected error for invalid style")
+	}
+
+	if _, err := NewNumberFormat("exact", "xx-XX"); err == nil {
+		t.Errorf("Expected error for unsupported locale")
+	}
+}
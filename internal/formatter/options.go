@@ -0,0 +1,110 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"wppanalyticscli/internal/datetime"
+)
+
+// Window is the requested reporting window as Unix epochs
+type Window struct {
+	Start int64
+	End   int64
+}
+
+// reportOptions holds settings shared by the report formatters
+type reportOptions struct {
+	window     *Window
+	sortBy     string
+	groupBy    string
+	currency   *Currency
+	numbers    *NumberFormat
+	chartWidth int
+	width      int
+	ascii      bool
+	color      bool
+}
+
+// SetNumbers sets how counts, percentages and amounts are displayed (default: short, en-US)
+func (o *reportOptions) SetNumbers(format NumberFormat) {
+	o.numbers = &format
+}
+
+// num returns the number format used by the report
+func (o *reportOptions) num() NumberFormat {
+	if o.numbers == nil {
+		return defaultNumbers
+	}
+	return *o.numbers
+}
+
+// count formats a count in the report's number format
+func (o *reportOptions) count(n int) string {
+	return o.num().Count(n)
+}
+
+// percent formats a percentage in the report's number format
+func (o *reportOptions) percent(value float64) string {
+	return o.num().Percent(value)
+}
+
+// cost formats an amount in the report's currency and number format
+func (o *reportOptions) cost(value float64) string {
+	return o.money().FormatIn(o.num(), value)
+}
+
+// costPerUnit formats a per-unit cost in the report's currency and number format
+func (o *reportOptions) costPerUnit(value float64) string {
+	return o.money().FormatUnitIn(o.num(), value)
+}
+
+// SetCurrency sets the ISO 4217 currency used to display costs (default: USD)
+func (o *reportOptions) SetCurrency(code string) {
+	currency := LookupCurrency(code)
+	o.currency = &currency
+}
+
+// money returns the currency used to display costs
+func (o *reportOptions) money() Currency {
+	if o.currency == nil {
+		return LookupCurrency("USD")
+	}
+	return *o.currency
+}
+
+// SetSort orders the report table by a column such as date, sent or cost
+func (o *reportOptions) SetSort(sortBy string) {
+	o.sortBy = sortBy
+}
+
+// SetGroupBy groups template rows by "template" (the default) or "date", with subtotals per group
+func (o *reportOptions) SetGroupBy(groupBy string) {
+	o.groupBy = groupBy
+}
+
+// grouping returns the template row grouping, defaulting to "template"
+func (o *reportOptions) grouping() string {
+	if o.groupBy == "" {
+		return "template"
+	}
+	return o.groupBy
+}
+
+// SetWindow records the requested window so it is echoed in the report header
+func (o *reportOptions) SetWindow(start, end int64) {
+	o.window = &Window{Start: start, End: end}
+}
+
+// writeWindow writes the requested window in both the display timezone and UTC
+func (o *reportOptions) writeWindow(output *strings.Builder, loc *time.Location) {
+	if o.window == nil {
+		return
+	}
+
+	start := datetime.ConvertEpochToLocal(o.window.Start, loc)
+	end := datetime.ConvertEpochToLocal(o.window.End, loc)
+	output.WriteString(fmt.Sprintf("🗓️  Window: %s → %s\n", start.Format("2006-01-02 15:04 MST"), end.Format("2006-01-02 15:04 MST")))
+	output.WriteString(fmt.Sprintf("   UTC: %s → %s\n", start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339)))
+}
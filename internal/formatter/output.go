@@ -15,7 +15,9 @@ type OutputFormatter interface {
 }
 
 // TableFormatter implements the OutputFormatter interface for table output
-type TableFormatter struct{}
+type TableFormatter struct {
+	reportOptions
+}
 
 // NewTableFormatter creates a new table formatter
 func NewTableFormatter() *TableFormatter {
@@ -29,7 +31,8 @@ func (f *TableFormatter) Format(response *models.AnalyticsResponse, loc *time.Lo
 	output.WriteString(fmt.Sprintf("📱 WhatsApp Business Account: %s\n", response.ID))
 	output.WriteString(fmt.Sprintf("📞 Phone Numbers: %s\n", strings.Join(response.Analytics.PhoneNumbers, ", ")))
 	output.WriteString(fmt.Sprintf("⏱️  Granularity: %s\n", response.Analytics.Granularity))
-	output.WriteString(fmt.Sprintf("📊 Data Points: %d\n", len(response.Analytics.DataPoints)))
+	output.WriteString(fmt.Sprintf("📊 Data Points: %s\n", f.count(len(response.Analytics.DataPoints))))
+	f.writeWindow(&output, loc)
 	output.WriteString(fmt.Sprintf("🌎 Timezone: %s\n\n", loc.String()))
 	
 	if len(response.Analytics.DataPoints) == 0 {
@@ -37,35 +40,61 @@ func (f *TableFormatter) Format(response *models.AnalyticsResponse, loc *time.Lo
 		return output.String()
 	}
 	
-	output.WriteString("╭──────────────┬─────────────────┬─────────────┬─────────────╮\n")
-	output.WriteString("│     Date     │   Time Range    │    Sent     │  Delivered  │\n")
-	output.WriteString("├──────────────┼─────────────────┼─────────────┼─────────────┤\n")
+	table := newTextTable(
+		tableColumn{header: "Date", width: 12},
+		tableColumn{header: "Time Range", width: 15},
+		tableColumn{header: "Sent", width: 11, align: alignRight},
+		tableColumn{header: "Delivered", width: 11, align: alignRight},
+	)
+	table.centerHeaders = true
 	
 	totalSent := 0
 	totalDelivered := 0
 	
-	for _, dp := range response.Analytics.DataPoints {
+	for _, dp := range sortedDataPoints(response.Analytics.DataPoints, f.sortBy) {
 		date, timeRange := formatTimeRange(dp.Start, dp.End, loc, response.Analytics.Granularity)
 		
-		output.WriteString(fmt.Sprintf("│ %-12s │ %-15s │ %11s │ %11s │\n",
-			date, timeRange, 
-			formatNumber(dp.Sent), 
-			formatNumber(dp.Delivered)))
+		table.addRow(date, timeRange, f.count(dp.Sent),
+			f.paintShare(f.count(dp.Delivered), dp.Delivered, dp.Sent, deliveryRateGood, deliveryRateWarn))
 		
 		totalSent += dp.Sent
 		totalDelivered += dp.Delivered
 	}
 	
-	output.WriteString("╰──────────────┴─────────────────┴─────────────┴─────────────╯\n")
+	f.renderTable(&output, table)
+	
+	if f.chartWidth > 0 {
+		f.writeAnalyticsCharts(&output, response.Analytics.DataPoints)
+	}
 	
 	output.WriteString(fmt.Sprintf("\n📈 Summary:\n"))
-	output.WriteString(fmt.Sprintf("   📤 Total Sent: %s\n", formatNumber(totalSent)))
-	output.WriteString(fmt.Sprintf("   📥 Total Delivered: %s\n", formatNumber(totalDelivered)))
+	output.WriteString(fmt.Sprintf("   📤 Total Sent: %s\n", f.count(totalSent)))
+	output.WriteString(fmt.Sprintf("   📥 Total Delivered: %s\n", f.count(totalDelivered)))
 	output.WriteString(fmt.Sprintf("   ℹ️  Note: Delivered messages may arrive after the reporting period\n"))
 	
 	return output.String()
 }
 
+// writeAnalyticsCharts writes sparklines of sent and delivered per bucket
+func (f *TableFormatter) writeAnalyticsCharts(output *strings.Builder, points []models.DataPoint) {
+	var sent, delivered []float64
+	maxSent, maxDelivered := 0, 0
+	for _, dp := range points {
+		sent = append(sent, float64(dp.Sent))
+		delivered = append(delivered, float64(dp.Delivered))
+		if dp.Sent > maxSent {
+			maxSent = dp.Sent
+		}
+		if dp.Delivered > maxDelivered {
+			maxDelivered = dp.Delivered
+		}
+	}
+	
+	output.WriteString("\n📉 Trend:\n")
+	writeSparkline(output, "Sent", sent, f.count(maxSent), f.chartWidth, f.ascii)
+	writeSparkline(output, "Delivered", delivered, f.count(maxDelivered), f.chartWidth, f.ascii)
+}
+
 // formatTimeRange formats the time range based on granularity
 func formatTimeRange(start, end int64, loc *time.Location, granularity string) (string, string) {
 	startTime := datetime.ConvertEpochToLocal(start, loc)
@@ -80,10 +109,13 @@ func formatTimeRange(start, end int64, loc *time.Location, granularity string) (
 		date := startTime.Format("2006-01")
 		timeRange := fmt.Sprintf("%s - %s", startTime.Format("Jan 02"), endTime.Format("Jan 02"))
 		return date, timeRange
-	case "HALF_HOUR":
+	case "HALF_HOUR", "HOUR":
 		date := startTime.Format("2006-01-02")
 		timeRange := fmt.Sprintf("%s - %s", startTime.Format("15:04"), endTime.Format("15:04"))
 		return date, timeRange
+	case "WEEK", "QUARTER", "YEAR":
+		timeRange := fmt.Sprintf("%s - %s", startTime.Format("Jan 02"), endTime.Format("Jan 02"))
+		return formatPeriodLabel(startTime, granularity), timeRange
 	default:
 		date := startTime.Format("2006-01-02")
 		timeRange := fmt.Sprintf("%s - %s", startTime.Format("15:04"), endTime.Format("15:04"))
@@ -91,12 +123,21 @@ func formatTimeRange(start, end int64, loc *time.Location, granularity string) (
 	}
 }
 
+// formatPeriodLabel formats the start of a bucket as a label for its granularity
+func formatPeriodLabel(t time.Time, granularity string) string {
+	switch strings.ToUpper(granularity) {
+	case "QUARTER":
+		return fmt.Sprintf("%d-Q%d", t.Year(), (int(t.Month())-1)/3+1)
+	case "YEAR":
+		return t.Format("2006")
+	case "MONTH":
+		return t.Format("2006-01")
+	default:
+		return t.Format("2006-01-02")
+	}
+}
+
 // formatNumber formats numbers with K/M suffixes
 func formatNumber(n int) string {
-	if n >= 1000000 {
-		return fmt.Sprintf("%.1fM", float64(n)/1000000)
-	} else if n >= 1000 {
-		return fmt.Sprintf("%.1fK", float64(n)/1000)
-	}
-	return fmt.Sprintf("%d", n)
+	return defaultNumbers.Count(n)
 }
\ No newline at end of file
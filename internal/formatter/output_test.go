@@ -111,4 +111,25 @@ func TestFormatNumber(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestTableFormatter_FormatWindow(t *testing.T) {
+	formatter := NewTableFormatter()
+	formatter.SetWindow(1750388400, 1750820400)
+	
+	response := &models.AnalyticsResponse{ID: "932157148829117"}
+	
+	loc, _ := time.LoadLocation("America/Sao_Paulo")
+	result := formatter.Format(response, loc)
+	
+	expectedStrings := []string{
+		"🗓️  Window: 2025-06-20 00:00 -03 → 2025-06-25 00:00 -03",
+		"UTC: 2025-06-20T03:00:00Z → 2025-06-25T03:00:00Z",
+	}
+	
+	for _, expected := range expectedStrings {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Formatted output doesn't contain expected string: %s\n%s", expected, result)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package formatter
+
+import (
+	"sort"
+	"strings"
+
+	"wppanalyticscli/internal/models"
+)
+
+// sortedDataPoints returns a copy of points ordered by sortBy: chronologically by
+// default, by descending value for "sent" and "delivered"
+func sortedDataPoints(points []models.DataPoint, sortBy string) []models.DataPoint {
+	sorted := append([]models.DataPoint(nil), points...)
+	sort.SliceStable(sorted, func(a, b int) bool {
+		switch sortBy {
+		case "sent":
+			if sorted[a].Sent != sorted[b].Sent {
+				return sorted[a].Sent > sorted[b].Sent
+			}
+		case "delivered":
+			if sorted[a].Delivered != sorted[b].Delivered {
+				return sorted[a].Delivered > sorted[b].Delivered
+			}
+		}
+		return sorted[a].Start < sorted[b].Start
+	})
+	return sorted
+}
+
+// sortedTemplates returns a copy of templates ordered by "name" or "status", then
+// by name and ID; without a sort key the API order is kept
+func sortedTemplates(templates []models.MessageTemplate, sortBy string) []models.MessageTemplate {
+	sorted := append([]models.MessageTemplate(nil), templates...)
+	if sortBy == "" {
+		return sorted
+	}
+	sort.SliceStable(sorted, func(a, b int) bool {
+		if sortBy == "status" {
+			statusA, statusB := strings.ToUpper(sorted[a].Status), strings.ToUpper(sorted[b].Status)
+			if statusA != statusB {
+				return statusA < statusB
+			}
+		}
+		if sorted[a].Name != sorted[b].Name {
+			return sorted[a].Name < sorted[b].Name
+		}
+		return sorted[a].ID < sorted[b].ID
+	})
+	return sorted
+}
+
+// sortedCountKeys returns the keys of counts, largest count first, then by key
+func sortedCountKeys(counts map[string]int) []string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(a, b int) bool {
+		if counts[keys[a]] != counts[keys[b]] {
+			return counts[keys[a]] > counts[keys[b]]
+		}
+		return keys[a] < keys[b]
+	})
+	return keys
+}
@@ -0,0 +1,344 @@
+package formatter
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// tableStyle holds the characters used to draw table borders
+type tableStyle struct {
+	topLeft, topMiddle, topRight          string
+	middleLeft, middleMiddle, middleRight string
+	bottomLeft, bottomMiddle, bottomRight string
+	horizontal, vertical                  string
+}
+
+// boxStyle draws tables with rounded Unicode box characters
+var boxStyle = tableStyle{
+	topLeft: "╭", topMiddle: "┬", topRight: "╮",
+	middleLeft: "├", middleMiddle: "┼", middleRight: "┤",
+	bottomLeft: "╰", bottomMiddle: "┴", bottomRight: "╯",
+	horizontal: "─", vertical: "│",
+}
+
+// asciiStyle draws tables with plain ASCII for logs and legacy consoles
+var asciiStyle = tableStyle{
+	topLeft: "+", topMiddle: "+", topRight: "+",
+	middleLeft: "+", middleMiddle: "+", middleRight: "+",
+	bottomLeft: "+", bottomMiddle: "+", bottomRight: "+",
+	horizontal: "-", vertical: "|",
+}
+
+// column alignments
+const (
+	alignLeft = iota
+	alignRight
+)
+
+// tableColumn describes one column of a text table
+type tableColumn struct {
+	header string
+	width  int  // default width in terminal cells
+	align  int  // alignLeft or alignRight
+	flex   bool // text that is truncated to the width, and resized to fit the terminal
+	min    int  // narrowest width when shrinking a flexible column (default 8)
+}
+
+// textTable is a table rendered with box-drawing or ASCII borders. Cells are
+// measured in terminal cells, so accents, wide characters and emoji line up.
+type textTable struct {
+	columns       []tableColumn
+	lines         []tableLine
+	centerHeaders bool
+}
+
+// tableLine is a row of cells or, when separator is set, a horizontal rule
+type tableLine struct {
+	cells     []string
+	separator bool
+}
+
+// newTextTable creates a table with the given columns
+func newTextTable(columns ...tableColumn) *textTable {
+	return &textTable{columns: columns}
+}
+
+// addRow appends a row of cells
+func (t *textTable) addRow(cells ...string) {
+	t.lines = append(t.lines, tableLine{cells: cells})
+}
+
+// addSeparator appends a horizontal rule between rows
+func (t *textTable) addSeparator() {
+	t.lines = append(t.lines, tableLine{separator: true})
+}
+
+// SetTableStyle draws tables with "box" (default) or "ascii" borders
+func (o *reportOptions) SetTableStyle(style string) {
+	o.ascii = style == "ascii"
+}
+
+// SetWidth fits tables to a terminal of width cells; 0 keeps the default column widths
+func (o *reportOptions) SetWidth(width int) {
+	o.width = width
+}
+
+// style returns the border characters for the report's tables
+func (o *reportOptions) style() tableStyle {
+	if o.ascii {
+		return asciiStyle
+	}
+	return boxStyle
+}
+
+// renderTable writes the table fitted to the report's width and style
+func (o *reportOptions) renderTable(output *strings.Builder, t *textTable) {
+	style := o.style()
+	widths := t.columnWidths(o.width)
+
+	rule := func(left, middle, right string) {
+		output.WriteString(left)
+		for i, width := range widths {
+			if i > 0 {
+				output.WriteString(middle)
+			}
+			output.WriteString(strings.Repeat(style.horizontal, width+2))
+		}
+		output.WriteString(right)
+		output.WriteString("\n")
+	}
+
+	row := func(cells []string, header bool) {
+		output.WriteString(style.vertical)
+		for i, column := range t.columns {
+			cell := ""
+			if i < len(cells) {
+				cell = cells[i]
+			}
+			if column.flex {
+				cell = truncateString(cell, widths[i])
+			}
+			align := column.align
+			if header && t.centerHeaders {
+				output.WriteString(" " + padCenter(cell, widths[i]) + " " + style.vertical)
+				continue
+			}
+			output.WriteString(" " + pad(cell, widths[i], align) + " " + style.vertical)
+		}
+		output.WriteString("\n")
+	}
+
+	headers := make([]string, len(t.columns))
+	for i, column := range t.columns {
+		headers[i] = column.header
+	}
+
+	rule(style.topLeft, style.topMiddle, style.topRight)
+	row(headers, true)
+	rule(style.middleLeft, style.middleMiddle, style.middleRight)
+	for _, line := range t.lines {
+		if line.separator {
+			rule(style.middleLeft, style.middleMiddle, style.middleRight)
+			continue
+		}
+		row(line.cells, false)
+	}
+	rule(style.bottomLeft, style.bottomMiddle, style.bottomRight)
+}
+
+// columnWidths returns the width of each column. Fixed columns grow to fit their
+// content; with a terminal width, flexible columns grow towards their content
+// while the table fits and shrink, widest first, when it does not.
+func (t *textTable) columnWidths(terminalWidth int) []int {
+	widths := make([]int, len(t.columns))
+	content := make([]int, len(t.columns))
+
+	for i, column := range t.columns {
+		content[i] = displayWidth(column.header)
+		for _, line := range t.lines {
+			if i < len(line.cells) {
+				if w := displayWidth(line.cells[i]); w > content[i] {
+					content[i] = w
+				}
+			}
+		}
+
+		widths[i] = column.width
+		if !column.flex && content[i] > widths[i] {
+			widths[i] = content[i]
+		}
+	}
+
+	if terminalWidth <= 0 {
+		return widths
+	}
+
+	total := func() int {
+		sum := 1
+		for _, width := range widths {
+			sum += width + 3
+		}
+		return sum
+	}
+
+	// Grow flexible columns towards their content while there is room
+	for i, column := range t.columns {
+		if column.flex && content[i] > widths[i] {
+			room := terminalWidth - total()
+			if room <= 0 {
+				break
+			}
+			grow := content[i] - widths[i]
+			if grow > room {
+				grow = room
+			}
+			widths[i] += grow
+		}
+	}
+
+	// Shrink the widest flexible column one cell at a time until the table fits
+	for total() > terminalWidth {
+		widest := -1
+		for i, column := range t.columns {
+			if !column.flex || widths[i] <= column.minWidth() {
+				continue
+			}
+			if widest < 0 || widths[i] > widths[widest] {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			break
+		}
+		widths[widest]--
+	}
+
+	return widths
+}
+
+// minWidth returns the narrowest width a flexible column may shrink to
+func (c tableColumn) minWidth() int {
+	if c.min > 0 {
+		return c.min
+	}
+	return 8
+}
+
+// pad aligns s within width terminal cells
+func pad(s string, width, align int) string {
+	gap := width - displayWidth(s)
+	if gap <= 0 {
+		return s
+	}
+	if align == alignRight {
+		return strings.Repeat(" ", gap) + s
+	}
+	return s + strings.Repeat(" ", gap)
+}
+
+// padCenter centres s within width terminal cells
+func padCenter(s string, width int) string {
+	gap := width - displayWidth(s)
+	if gap <= 0 {
+		return s
+	}
+	left := gap / 2
+	return strings.Repeat(" ", left) + s + strings.Repeat(" ", gap-left)
+}
+
+// truncateString shortens s to at most maxLen terminal cells, ending with "..."
+// when cut. It never splits a rune, so accented names stay valid UTF-8, and it
+// keeps ANSI colors, resetting them after a cut.
+func truncateString(s string, maxLen int) string {
+	if displayWidth(s) <= maxLen {
+		return s
+	}
+
+	ellipsis := "..."
+	if maxLen < len(ellipsis) {
+		ellipsis = ""
+	}
+
+	var truncated strings.Builder
+	width := 0
+	colored := false
+	for i := 0; i < len(s); {
+		if n := skipANSI(s[i:]); n > 0 {
+			truncated.WriteString(s[i : i+n])
+			colored = true
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		w := runeWidth(r)
+		if width+w > maxLen-len(ellipsis) {
+			break
+		}
+		truncated.WriteRune(r)
+		width += w
+		i += size
+	}
+	if colored {
+		return truncated.String() + ellipsis + ansiReset
+	}
+	return truncated.String() + ellipsis
+}
+
+// displayWidth returns the number of terminal cells s occupies, ignoring ANSI colors
+func displayWidth(s string) int {
+	width := 0
+	for i := 0; i < len(s); {
+		if n := skipANSI(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		width += runeWidth(r)
+		i += size
+	}
+	return width
+}
+
+// runeWidth returns the terminal cells of a rune: 0 for combining marks and
+// invisible format characters, 2 for East Asian wide characters and emoji
+func runeWidth(r rune) int {
+	switch {
+	case r == 0 || unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r) || unicode.Is(unicode.Cf, r):
+		return 0
+	case r >= 0xFE00 && r <= 0xFE0F: // variation selectors
+		return 0
+	case isWide(r):
+		return 2
+	default:
+		return 1
+	}
+}
+
+// wideRanges lists East Asian wide and fullwidth blocks and emoji shown two cells wide
+var wideRanges = [][2]rune{
+	{0x1100, 0x115F}, {0x231A, 0x231B}, {0x23E9, 0x23EC}, {0x23F0, 0x23F0}, {0x23F3, 0x23F3},
+	{0x25FD, 0x25FE}, {0x2614, 0x2615}, {0x2648, 0x2653}, {0x267F, 0x267F}, {0x2693, 0x2693},
+	{0x26A1, 0x26A1}, {0x26AA, 0x26AB}, {0x26BD, 0x26BE}, {0x26C4, 0x26C5}, {0x26CE, 0x26CE},
+	{0x26D4, 0x26D4}, {0x26EA, 0x26EA}, {0x26F2, 0x26F3}, {0x26F5, 0x26F5}, {0x26FA, 0x26FA},
+	{0x26FD, 0x26FD}, {0x2705, 0x2705}, {0x270A, 0x270B}, {0x2728, 0x2728}, {0x274C, 0x274C},
+	{0x274E, 0x274E}, {0x2753, 0x2755}, {0x2757, 0x2757}, {0x2795, 0x2797}, {0x27B0, 0x27B0},
+	{0x27BF, 0x27BF}, {0x2B1B, 0x2B1C}, {0x2B50, 0x2B50}, {0x2B55, 0x2B55},
+	{0x2E80, 0x303E}, {0x3041, 0x33FF}, {0x3400, 0x4DBF}, {0x4E00, 0x9FFF}, {0xA000, 0xA4CF},
+	{0xAC00, 0xD7A3}, {0xF900, 0xFAFF}, {0xFE30, 0xFE4F}, {0xFF00, 0xFF60}, {0xFFE0, 0xFFE6},
+	{0x1F300, 0x1F64F}, {0x1F680, 0x1F6FF}, {0x1F900, 0x1F9FF}, {0x1FA70, 0x1FAFF},
+	{0x20000, 0x3FFFD},
+}
+
+// isWide reports whether r is drawn two cells wide
+func isWide(r rune) bool {
+	if r < 0x1100 {
+		return false
+	}
+	for _, span := range wideRanges {
+		if r >= span[0] && r <= span[1] {
+			return true
+		}
+	}
+	return false
+}
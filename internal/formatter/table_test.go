@@ -0,0 +1,90 @@
+package formatter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+	}{
+		{"promo", 5},
+		{"promoção", 8},
+		{"café", 4},
+		{"日本語", 6},
+		{"✅ OK", 5},
+		{"🗑️", 2},
+	}
+
+	for _, tt := range tests {
+		if result := displayWidth(tt.input); result != tt.expected {
+			t.Errorf("displayWidth(%q): expected %d, got %d", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestRenderTable_UnicodeAlignment(t *testing.T) {
+	var options reportOptions
+	table := newTextTable(
+		tableColumn{header: "Name", width: 10, flex: true},
+		tableColumn{header: "Sent", width: 5, align: alignRight},
+	)
+	table.addRow("promoção", "12")
+	table.addRow("日本語", "3")
+
+	var output strings.Builder
+	options.renderTable(&output, table)
+
+	expected := "╭────────────┬───────╮\n" +
+		"│ Name       │  Sent │\n" +
+		"├────────────┼───────┤\n" +
+		"│ promoção   │    12 │\n" +
+		"│ 日本語     │     3 │\n" +
+		"╰────────────┴───────╯\n"
+
+	if output.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, output.String())
+	}
+}
+
+func TestRenderTable_ASCIIAndWidth(t *testing.T) {
+	var options reportOptions
+	options.SetTableStyle("ascii")
+	options.SetWidth(30)
+
+	table := newTextTable(
+		tableColumn{header: "Template", width: 20, flex: true},
+		tableColumn{header: "Sent", width: 8, align: alignRight},
+	)
+	table.addRow("promoção_cartão_de_crédito", "1.2K")
+
+	var output strings.Builder
+	options.renderTable(&output, table)
+
+	expected := "+-----------------+----------+\n" +
+		"| Template        |     Sent |\n" +
+		"+-----------------+----------+\n" +
+		"| promoção_car... |     1.2K |\n" +
+		"+-----------------+----------+\n"
+
+	if output.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, output.String())
+	}
+}
+
+func TestRenderTable_NumericColumnsGrow(t *testing.T) {
+	var options reportOptions
+	table := newTextTable(
+		tableColumn{header: "Sent", width: 4, align: alignRight},
+	)
+	table.addRow("1,234,567")
+
+	var output strings.Builder
+	options.renderTable(&output, table)
+
+	if !strings.Contains(output.String(), "│ 1,234,567 │") {
+		t.Errorf("Expected numeric column to grow to fit its content\n%s", output.String())
+	}
+}
@@ -2,6 +2,7 @@ package formatter
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -10,7 +11,9 @@ import (
 )
 
 // TemplateFormatter implements the OutputFormatter interface for template analytics
-type TemplateFormatter struct{}
+type TemplateFormatter struct {
+	reportOptions
+}
 
 // NewTemplateFormatter creates a new template formatter
 func NewTemplateFormatter() *TemplateFormatter {
@@ -31,7 +34,8 @@ func (f *TemplateFormatter) FormatTemplate(response *models.TemplateAnalyticsRes
 	output.WriteString(fmt.Sprintf("📊 Template Analytics Report\n"))
 	output.WriteString(fmt.Sprintf("📈 Granularity: %s\n", strings.ToUpper(data.Granularity)))
 	output.WriteString(fmt.Sprintf("🔧 Product Type: %s\n", strings.ToUpper(data.ProductType)))
-	output.WriteString(fmt.Sprintf("📋 Data Points: %d\n", len(data.DataPoints)))
+	output.WriteString(fmt.Sprintf("📋 Data Points: %s\n", f.count(len(data.DataPoints))))
+	f.writeWindow(&output, loc)
 	output.WriteString(fmt.Sprintf("🌎 Timezone: %s\n\n", loc.String()))
 	
 	if len(data.DataPoints) == 0 {
@@ -39,112 +43,186 @@ func (f *TemplateFormatter) FormatTemplate(response *models.TemplateAnalyticsRes
 		return output.String()
 	}
 	
-	// Table header
-	output.WriteString("╭──────────────┬─────────────────┬──────────┬───────────┬──────────┬──────────┬───────────┬──────────────╮\n")
-	output.WriteString("│     Date     │  Template ID    │   Sent   │ Delivered │   Read   │ Clicked  │   Cost    │ Click Rate % │\n")
-	output.WriteString("├──────────────┼─────────────────┼──────────┼───────────┼──────────┼──────────┼───────────┼──────────────┤\n")
+	groupBy := f.grouping()
+	groups := groupTemplatePoints(data.DataPoints, groupBy, f.sortBy, loc, data.Granularity)
 	
-	totalSent := 0
-	totalDelivered := 0
-	totalRead := 0
-	totalClicked := 0
-	totalCost := 0.0
+	table := newTextTable(
+		tableColumn{header: "Date", width: 12, flex: true, min: 10},
+		tableColumn{header: "Template ID", width: 15, flex: true, min: 10},
+		tableColumn{header: "Sent", width: 8, align: alignRight},
+		tableColumn{header: "Delivered", width: 9, align: alignRight},
+		tableColumn{header: "Read", width: 8, align: alignRight},
+		tableColumn{header: "Clicked", width: 8, align: alignRight},
+		tableColumn{header: "Cost", width: 9, align: alignRight},
+		tableColumn{header: "Click Rate %", width: 12, align: alignRight},
+	)
+	table.centerHeaders = true
 	
-	for _, dp := range data.DataPoints {
-		date := formatTemplateDate(dp.Start, loc)
-		templateID := truncateString(dp.TemplateID, 15)
-		
-		// Calculate total clicks
-		clicks := 0
-		for _, clicked := range dp.Clicked {
-			clicks += clicked.Count
+	for i, group := range groups {
+		for _, dp := range group.points {
+			var rowTotals templateTotals
+			rowTotals.add(dp)
+			f.addTemplateRow(table, formatTemplateDate(dp.Start, loc, data.Granularity), dp.TemplateID, rowTotals)
 		}
 		
-		// Calculate total cost (amount_spent)
-		cost := 0.0
-		for _, costMetric := range dp.Cost {
-			if costMetric.Type == "amount_spent" {
-				cost = costMetric.Value
-				break
+		// Subtotal for groups with more than one row
+		if len(group.points) > 1 {
+			if groupBy == "date" {
+				f.addTemplateRow(table, group.key, "Σ Subtotal", group.totals)
+			} else {
+				f.addTemplateRow(table, "Σ Subtotal", group.key, group.totals)
 			}
 		}
 		
-		// Calculate click rate
-		clickRate := float64(0)
-		if dp.Delivered > 0 {
-			clickRate = (float64(clicks) / float64(dp.Delivered)) * 100
+		if i < len(groups)-1 {
+			table.addSeparator()
 		}
-		
-		output.WriteString(fmt.Sprintf("│ %-12s │ %-15s │ %8s │ %9s │ %8s │ %8s │ %9s │ %11.1f%% │\n",
-			date, templateID,
-			formatNumber(dp.Sent),
-			formatNumber(dp.Delivered),
-			formatNumber(dp.Read),
-			formatNumber(clicks),
-			fmt.Sprintf("$%.2f", cost),
-			clickRate))
-		
-		totalSent += dp.Sent
-		totalDelivered += dp.Delivered
-		totalRead += dp.Read
-		totalClicked += clicks
-		totalCost += cost
 	}
 	
-	output.WriteString("╰──────────────┴─────────────────┴──────────┴───────────┴──────────┴──────────┴───────────┴──────────────╯\n")
+	f.renderTable(&output, table)
 	
-	// Summary
-	overallClickRate := float64(0)
-	if totalDelivered > 0 {
-		overallClickRate = (float64(totalClicked) / float64(totalDelivered)) * 100
+	if f.chartWidth > 0 {
+		f.writeTemplateCharts(&output, data.DataPoints, groupTemplatePoints(data.DataPoints, "template", f.sortBy, loc, data.Granularity))
 	}
 	
-	readRate := float64(0)
-	if totalDelivered > 0 {
-		readRate = (float64(totalRead) / float64(totalDelivered)) * 100
-	}
+	// Summary
+	totals := sumTemplatePoints(data.DataPoints)
+	totalSent := totals.Sent
+	totalDelivered := totals.Delivered
+	totalRead := totals.Read
+	totalClicked := totals.Clicked
+	totalCost := totals.Cost
+	
+	overallClickRate := percentage(totalClicked, totalDelivered)
+	readRate := percentage(totalRead, totalDelivered)
 	
 	output.WriteString(fmt.Sprintf("\n📈 Summary:\n"))
-	output.WriteString(fmt.Sprintf("   📤 Total Sent: %s\n", formatNumber(totalSent)))
-	output.WriteString(fmt.Sprintf("   📥 Total Delivered: %s\n", formatNumber(totalDelivered)))
-	output.WriteString(fmt.Sprintf("   👀 Total Read: %s (%.1f%%)\n", formatNumber(totalRead), readRate))
-	output.WriteString(fmt.Sprintf("   👆 Total Clicked: %s (%.1f%%)\n", formatNumber(totalClicked), overallClickRate))
-	output.WriteString(fmt.Sprintf("   💰 Total Cost: $%.2f\n", totalCost))
+	output.WriteString(fmt.Sprintf("   📤 Total Sent: %s\n", f.count(totalSent)))
+	output.WriteString(fmt.Sprintf("   📥 Total Delivered: %s\n", f.count(totalDelivered)))
+	output.WriteString(fmt.Sprintf("   👀 Total Read: %s (%s)\n", f.count(totalRead), f.percent(readRate)))
+	output.WriteString(fmt.Sprintf("   👆 Total Clicked: %s (%s)\n", f.count(totalClicked), f.percent(overallClickRate)))
+	output.WriteString(fmt.Sprintf("   💰 Total Cost: %s\n", f.cost(totalCost)))
 	
 	if totalCost > 0 && totalDelivered > 0 {
 		costPerDelivered := totalCost / float64(totalDelivered)
-		output.WriteString(fmt.Sprintf("   📊 Cost per Delivered: $%.4f\n", costPerDelivered))
+		output.WriteString(fmt.Sprintf("   📊 Cost per Delivered: %s\n", f.costPerUnit(costPerDelivered)))
+	}
+	
+	if totalCost > 0 && totalRead > 0 {
+		output.WriteString(fmt.Sprintf("   📊 Cost per Read: %s\n", f.costPerUnit(totalCost/float64(totalRead))))
+	}
+	
+	if totalCost > 0 && totalClicked > 0 {
+		output.WriteString(fmt.Sprintf("   📊 Cost per Click: %s\n", f.costPerUnit(totalCost/float64(totalClicked))))
+	}
+	
+	// Per-template summary when several templates were queried
+	templateGroups := groups
+	if groupBy == "date" {
+		templateGroups = groupTemplatePoints(data.DataPoints, "template", f.sortBy, loc, data.Granularity)
+	}
+	if len(templateGroups) > 1 {
+		f.writeTemplateSummary(&output, templateGroups)
+	}
+	
+	// Every cost metric per template when costs were requested
+	if hasCostMetrics(data.DataPoints) {
+		f.writeCostBreakdown(&output, templateGroups, data.DataPoints)
 	}
 	
 	// Click details if available
 	if len(data.DataPoints) > 0 && len(data.DataPoints[0].Clicked) > 0 {
 		output.WriteString(fmt.Sprintf("\n🔗 Click Details:\n"))
-		clickSummary := make(map[string]int)
-		
-		for _, dp := range data.DataPoints {
-			for _, clicked := range dp.Clicked {
-				key := fmt.Sprintf("%s: %s", clicked.Type, clicked.ButtonContent)
-				clickSummary[key] += clicked.Count
-			}
-		}
-		
-		for action, count := range clickSummary {
-			output.WriteString(fmt.Sprintf("   • %s: %d clicks\n", action, count))
+		for _, item := range f.clickDetailItems(data.DataPoints) {
+			output.WriteString(fmt.Sprintf("   • %s\n", item))
 		}
 	}
 	
 	return output.String()
 }
 
-// formatTemplateDate formats the date for template analytics
-func formatTemplateDate(epoch int64, loc *time.Location) string {
-	return datetime.ConvertEpochToLocal(epoch, loc).Format("2006-01-02")
+// addTemplateRow adds one row to the template analytics table
+func (f *TemplateFormatter) addTemplateRow(table *textTable, date, templateID string, totals templateTotals) {
+	table.addRow(date, templateID,
+		f.count(totals.Sent),
+		f.count(totals.Delivered),
+		f.paintShare(f.count(totals.Read), totals.Read, totals.Delivered, readRateGood, readRateWarn),
+		f.count(totals.Clicked),
+		f.cost(totals.Cost),
+		f.paintShare(f.percent(percentage(totals.Clicked, totals.Delivered)), totals.Clicked, totals.Delivered, clickRateGood, clickRateWarn))
+}
+
+// writeTemplateSummary writes a compact table with one line of totals per template
+func (f *TemplateFormatter) writeTemplateSummary(output *strings.Builder, groups []templateGroup) {
+	table := newTextTable(
+		tableColumn{header: "Template ID", width: 15, flex: true, min: 10},
+		tableColumn{header: "Sent", width: 8, align: alignRight},
+		tableColumn{header: "Delivered", width: 9, align: alignRight},
+		tableColumn{header: "Read", width: 8, align: alignRight},
+		tableColumn{header: "Clicked", width: 8, align: alignRight},
+		tableColumn{header: "Cost", width: 9, align: alignRight},
+		tableColumn{header: "Read%", width: 7, align: alignRight},
+		tableColumn{header: "Click%", width: 7, align: alignRight},
+	)
+	
+	for _, group := range groups {
+		totals := group.totals
+		table.addRow(group.key,
+			f.count(totals.Sent),
+			f.count(totals.Delivered),
+			f.count(totals.Read),
+			f.count(totals.Clicked),
+			f.cost(totals.Cost),
+			f.paintShare(f.percent(percentage(totals.Read, totals.Delivered)), totals.Read, totals.Delivered, readRateGood, readRateWarn),
+			f.paintShare(f.percent(percentage(totals.Clicked, totals.Delivered)), totals.Clicked, totals.Delivered, clickRateGood, clickRateWarn))
+	}
+	
+	output.WriteString("\n📋 Per-Template Summary:\n")
+	f.renderTable(output, table)
 }
 
-// truncateString truncates a string to a maximum length
-func truncateString(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
+// writeTemplateCharts writes bar charts of clicks per button and cost per template
+func (f *TemplateFormatter) writeTemplateCharts(output *strings.Builder, points []models.TemplateDataPoint, templateGroups []templateGroup) {
+	var buttons []string
+	clicks := make(map[string]int)
+	for _, dp := range points {
+		for _, clicked := range dp.Clicked {
+			key := fmt.Sprintf("%s: %s", clicked.Type, clicked.ButtonContent)
+			if _, ok := clicks[key]; !ok {
+				buttons = append(buttons, key)
+			}
+			clicks[key] += clicked.Count
+		}
 	}
-	return s[:maxLen-3] + "..."
-}
\ No newline at end of file
+	
+	if len(buttons) > 0 {
+		sort.SliceStable(buttons, func(a, b int) bool {
+			return clicks[buttons[a]] > clicks[buttons[b]]
+		})
+		var values []float64
+		var formatted []string
+		for _, button := range buttons {
+			values = append(values, float64(clicks[button]))
+			formatted = append(formatted, f.count(clicks[button]))
+		}
+		output.WriteString("\n🔘 Clicks per Button:\n")
+		writeBarChart(output, buttons, values, formatted, f.chartWidth, f.ascii)
+	}
+	
+	if sumTemplatePoints(points).Cost > 0 {
+		var labels, formatted []string
+		var values []float64
+		for _, group := range templateGroups {
+			labels = append(labels, group.key)
+			values = append(values, group.totals.Cost)
+			formatted = append(formatted, f.cost(group.totals.Cost))
+		}
+		output.WriteString("\n💰 Cost per Template:\n")
+		writeBarChart(output, labels, values, formatted, f.chartWidth, f.ascii)
+	}
+}
+
+// formatTemplateDate formats the date for template analytics, labelling rolled up periods
+func formatTemplateDate(epoch int64, loc *time.Location, granularity string) string {
+	return formatPeriodLabel(datetime.ConvertEpochToLocal(epoch, loc), granularity)
+}
@@ -121,6 +121,8 @@ func TestTruncateString(t *testing.T) {
 		{"this is a very long string", 10, "this is..."},
 		{"exactly10c", 10, "exactly10c"},
 		{"", 5, ""},
+		{"promoção_cartão", 10, "promoçã..."},
+		{"日本語テンプレート", 9, "日本語..."},
 	}
 
 	for _, tt := range tests {
@@ -131,4 +133,153 @@ func TestTruncateString(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func groupingTestResponse() *models.TemplateAnalyticsResponse {
+	return &models.TemplateAnalyticsResponse{
+		Data: []models.TemplateAnalyticsData{
+			{
+				Granularity: "DAILY",
+				ProductType: "cloud_api",
+				DataPoints: []models.TemplateDataPoint{
+					{TemplateID: "1111", Start: 1750377600, End: 1750464000, Sent: 100, Delivered: 90, Read: 45,
+						Cost: []models.CostMetric{{Type: "amount_spent", Value: 1.00}}},
+					{TemplateID: "2222", Start: 1750377600, End: 1750464000, Sent: 300, Delivered: 280, Read: 100,
+						Cost: []models.CostMetric{{Type: "amount_spent", Value: 3.00}}},
+					{TemplateID: "1111", Start: 1750464000, End: 1750550400, Sent: 50, Delivered: 40, Read: 20,
+						Cost: []models.CostMetric{{Type: "amount_spent", Value: 0.50}}},
+					{TemplateID: "2222", Start: 1750464000, End: 1750550400, Sent: 10, Delivered: 10, Read: 5,
+						Cost: []models.CostMetric{{Type: "amount_spent", Value: 0.10}}},
+				},
+			},
+		},
+	}
+}
+
+func TestTemplateFormatter_GroupByTemplate(t *testing.T) {
+	formatter := NewTemplateFormatter()
+	formatter.SetSort("sent")
+
+	loc, _ := time.LoadLocation("UTC")
+	result := formatter.FormatTemplate(groupingTestResponse(), loc)
+
+	expectedStrings := []string{
+		"│ Σ Subtotal   │ 1111            │      150 │       130 │       65 │        0 │     $1.50 │         0.0% │",
+		"│ Σ Subtotal   │ 2222            │      310 │       290 │      105 │        0 │     $3.10 │         0.0% │",
+		"📋 Per-Template Summary:",
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Formatted output doesn't contain expected string: %s\n%s", expected, result)
+		}
+	}
+
+	// Sorted by sent, template 2222 comes first
+	if strings.Index(result, "│ Σ Subtotal   │ 2222") > strings.Index(result, "│ Σ Subtotal   │ 1111") {
+		t.Errorf("Expected template groups sorted by sent descending\n%s", result)
+	}
+}
+
+func TestTemplateFormatter_GroupByDate(t *testing.T) {
+	formatter := NewTemplateFormatter()
+	formatter.SetGroupBy("date")
+
+	loc, _ := time.LoadLocation("UTC")
+	result := formatter.FormatTemplate(groupingTestResponse(), loc)
+
+	expectedStrings := []string{
+		"│ 2025-06-20   │ Σ Subtotal      │      400 │       370 │      145 │",
+		"│ 2025-06-21   │ Σ Subtotal      │       60 │        50 │       25 │",
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Formatted output doesn't contain expected string: %s\n%s", expected, result)
+		}
+	}
+}
+
+func TestTemplateFormatter_CostBreakdown(t *testing.T) {
+	formatter := NewTemplateFormatter()
+	formatter.SetCurrency("BRL")
+
+	response := &models.TemplateAnalyticsResponse{
+		Data: []models.TemplateAnalyticsData{
+			{
+				Granularity: "DAILY",
+				ProductType: "cloud_api",
+				DataPoints: []models.TemplateDataPoint{
+					{
+						TemplateID: "1026573095658757", Start: 1750377600, End: 1750464000,
+						Sent: 900, Delivered: 800, Read: 400,
+						Clicked: []models.ClickedAction{{Type: "url_button", ButtonContent: "Pagar", Count: 50}},
+						Cost: []models.CostMetric{
+							{Type: "amount_spent", Value: 8.00},
+							{Type: "cost_per_delivered", Value: 0.01},
+							{Type: "cost_per_url_button_click", Value: 0.16},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	loc, _ := time.LoadLocation("UTC")
+	result := formatter.FormatTemplate(response, loc)
+
+	expectedStrings := []string{
+		"💰 Total Cost: R$ 8.00",
+		"📊 Cost per Delivered: R$ 0.0100",
+		"📊 Cost per Read: R$ 0.0200",
+		"📊 Cost per Click: R$ 0.1600",
+		"💰 Cost Breakdown (BRL):",
+		"│ 102657309565... │     R$ 8.00 │     R$ 0.0100 │    R$ 0.0200* │    R$ 0.1600* │     R$ 0.1600 │",
+		"* derived locally from amount spent",
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Formatted output doesn't contain expected string: %s\n%s", expected, result)
+		}
+	}
+}
+
+func TestTemplateFormatter_NumberLocale(t *testing.T) {
+	formatter := NewTemplateFormatter()
+	formatter.SetCurrency("BRL")
+	numbers, _ := NewNumberFormat("exact", "pt-BR")
+	formatter.SetNumbers(numbers)
+
+	response := &models.TemplateAnalyticsResponse{
+		Data: []models.TemplateAnalyticsData{
+			{
+				Granularity: "DAILY",
+				ProductType: "cloud_api",
+				DataPoints: []models.TemplateDataPoint{
+					{
+						TemplateID: "1026573095658757", Start: 1750377600, End: 1750464000,
+						Sent: 12345, Delivered: 12000, Read: 6000,
+						Cost: []models.CostMetric{{Type: "amount_spent", Value: 1234.5}},
+					},
+				},
+			},
+		},
+	}
+
+	loc, _ := time.LoadLocation("UTC")
+	result := formatter.FormatTemplate(response, loc)
+
+	expectedStrings := []string{
+		"📤 Total Sent: 12.345",
+		"👀 Total Read: 6.000 (50,0%)",
+		"💰 Total Cost: R$ 1.234,50",
+		"│   12.345 │    12.000 │",
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Formatted output doesn't contain expected string: %s\n%s", expected, result)
+		}
+	}
+}
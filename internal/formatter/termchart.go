@@ -0,0 +1,146 @@
+package formatter
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// sparkTicks are the block characters of a sparkline, lowest to highest
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+// barEighths are the partial blocks used for the fractional end of a bar
+var barEighths = []rune(" ▏▎▍▌▋▊▉")
+
+// asciiSparkTicks replace sparkTicks in ASCII output (-plain, -table-style=ascii)
+var asciiSparkTicks = []rune("_.-:=+*#")
+
+// asciiBarCell fills whole cells of a bar in ASCII output, which has no partial blocks
+const asciiBarCell = "#"
+
+// SetChart enables terminal charts sized to width columns; 0 disables them
+func (o *reportOptions) SetChart(width int) {
+	o.chartWidth = width
+}
+
+// sparkline draws values as a row of ticks at most width runes wide, averaging
+// neighbouring values when there are more values than columns
+func sparkline(values []float64, width int, ticks []rune) string {
+	if len(values) == 0 || width <= 0 {
+		return ""
+	}
+
+	if len(values) > width {
+		values = downsample(values, width)
+	}
+
+	maxValue := 0.0
+	for _, v := range values {
+		if v > maxValue {
+			maxValue = v
+		}
+	}
+
+	var line strings.Builder
+	for _, v := range values {
+		tick := 0
+		if maxValue > 0 && v > 0 {
+			tick = int(math.Round(v / maxValue * float64(len(ticks)-1)))
+		}
+		line.WriteRune(ticks[tick])
+	}
+	return line.String()
+}
+
+// downsample averages values into width buckets
+func downsample(values []float64, width int) []float64 {
+	buckets := make([]float64, width)
+	for i := range buckets {
+		from := i * len(values) / width
+		to := (i + 1) * len(values) / width
+		sum := 0.0
+		for _, v := range values[from:to] {
+			sum += v
+		}
+		buckets[i] = sum / float64(to-from)
+	}
+	return buckets
+}
+
+// writeSparkline writes a labelled sparkline with its peak value, fitted to width,
+// in ASCII characters when ascii is set
+func writeSparkline(output *strings.Builder, label string, values []float64, peak string, width int, ascii bool) {
+	prefix := fmt.Sprintf("   %-10s ", label)
+	suffix := fmt.Sprintf(" peak %s", peak)
+	available := width - displayWidth(prefix) - displayWidth(suffix)
+	if available < 1 {
+		available = 1
+	}
+	ticks := sparkTicks
+	if ascii {
+		ticks = asciiSparkTicks
+	}
+	output.WriteString(prefix + sparkline(values, available, ticks) + suffix + "\n")
+}
+
+// writeBarChart writes one horizontal bar per label, scaled to the largest value
+// and fitted to width columns, in ASCII characters when ascii is set
+func writeBarChart(output *strings.Builder, labels []string, values []float64, formatted []string, width int, ascii bool) {
+	labelWidth := 0
+	for _, label := range labels {
+		if n := displayWidth(label); n > labelWidth {
+			labelWidth = n
+		}
+	}
+	if labelWidth > 24 {
+		labelWidth = 24
+	}
+
+	valueWidth := 0
+	maxValue := 0.0
+	for i, value := range values {
+		if n := displayWidth(formatted[i]); n > valueWidth {
+			valueWidth = n
+		}
+		if value > maxValue {
+			maxValue = value
+		}
+	}
+
+	barWidth := width - 3 - labelWidth - 1 - 1 - valueWidth
+	if barWidth < 1 {
+		barWidth = 1
+	}
+
+	for i, label := range labels {
+		output.WriteString(fmt.Sprintf("   %s %s %s\n",
+			pad(truncateString(label, labelWidth), labelWidth, alignLeft),
+			bar(values[i], maxValue, barWidth, ascii), formatted[i]))
+	}
+}
+
+// bar draws value as a horizontal bar of at most width cells, with eighth-cell
+// precision, or whole-cell precision in ASCII
+func bar(value, maxValue float64, width int, ascii bool) string {
+	if maxValue <= 0 || value <= 0 {
+		return strings.Repeat(" ", width)
+	}
+
+	if ascii {
+		full := int(math.Round(value / maxValue * float64(width)))
+		return strings.Repeat(asciiBarCell, full) + strings.Repeat(" ", width-full)
+	}
+
+	eighths := int(math.Round(value / maxValue * float64(width*8)))
+	full, partial := eighths/8, eighths%8
+
+	var b strings.Builder
+	b.WriteString(strings.Repeat("█", full))
+	cells := full
+	if partial > 0 {
+		b.WriteRune(barEighths[partial])
+		cells++
+	}
+	b.WriteString(strings.Repeat(" ", width-cells))
+	return b.String()
+}
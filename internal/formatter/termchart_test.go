@@ -0,0 +1,94 @@
+package formatter
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []float64
+		width    int
+		ticks    []rune
+		expected string
+	}{
+		{"Rising", []float64{0, 1, 2, 3, 4, 5, 6, 7}, 20, sparkTicks, "▁▂▃▄▅▆▇█"},
+		{"All zero", []float64{0, 0, 0}, 20, sparkTicks, "▁▁▁"},
+		{"Downsampled", []float64{0, 0, 7, 7}, 2, sparkTicks, "▁█"},
+		{"Empty", nil, 20, sparkTicks, ""},
+		{"ASCII", []float64{0, 1, 2, 3, 4, 5, 6, 7}, 20, asciiSparkTicks, "_.-:=+*#"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := sparkline(tt.values, tt.width, tt.ticks); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestBar(t *testing.T) {
+	tests := []struct {
+		value    float64
+		ascii    bool
+		expected string
+	}{
+		{10, false, "██████████"},
+		{5, false, "█████     "},
+		{0.5, false, "▌         "},
+		{0, false, "          "},
+		{10, true, "##########"},
+		{4.6, true, "#####     "},
+		{0.5, true, "#         "},
+	}
+
+	for _, tt := range tests {
+		if result := bar(tt.value, 10, 10, tt.ascii); result != tt.expected {
+			t.Errorf("bar(%v, ascii %v): expected '%s', got '%s'", tt.value, tt.ascii, tt.expected, result)
+		}
+	}
+}
+
+func TestTemplateFormatter_Charts(t *testing.T) {
+	formatter := NewTemplateFormatter()
+	formatter.SetChart(60)
+
+	loc, _ := time.LoadLocation("UTC")
+	result := formatter.FormatTemplate(groupingTestResponse(), loc)
+
+	expectedStrings := []string{
+		"💰 Cost per Template:",
+		"   2222 ██████████████████████████████████████████████ $3.10",
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Formatted output doesn't contain expected string: %s\n%s", expected, result)
+		}
+	}
+
+	for _, line := range strings.Split(result, "\n") {
+		if strings.Contains(line, "█") && len([]rune(line)) > 60 {
+			t.Errorf("Chart line wider than 60 columns: %q", line)
+		}
+	}
+}
+
+func TestTemplateFormatter_ChartsASCII(t *testing.T) {
+	formatter := NewTemplateFormatter()
+	formatter.SetChart(60)
+	formatter.SetTableStyle("ascii")
+
+	loc, _ := time.LoadLocation("UTC")
+	result := formatter.FormatTemplate(groupingTestResponse(), loc)
+
+	if !strings.Contains(result, "   2222 ############################################## $3.10") {
+		t.Errorf("Expected an ASCII cost bar\n%s", result)
+	}
+	if strings.ContainsAny(result, string(barEighths[1:])+"█") {
+		t.Errorf("Expected no block characters in ASCII charts\n%s", result)
+	}
+}
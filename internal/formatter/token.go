@@ -0,0 +1,98 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"wppanalyticscli/internal/datetime"
+	"wppanalyticscli/internal/models"
+)
+
+// TokenFormatter implements the formatter for access token inspection
+type TokenFormatter struct{}
+
+// NewTokenFormatter creates a new token formatter
+func NewTokenFormatter() *TokenFormatter {
+	return &TokenFormatter{}
+}
+
+// FormatTokenInfo formats debug_token data along with any warnings found while checking it
+func (f *TokenFormatter) FormatTokenInfo(data *models.TokenDebugData, loc *time.Location, warnings []string) string {
+	var output strings.Builder
+
+	output.WriteString("🔑 Access Token Info\n")
+	if data.IsValid {
+		output.WriteString("✅ Valid: yes\n")
+	} else {
+		output.WriteString("❌ Valid: no\n")
+		if data.Error != nil && data.Error.Message != "" {
+			output.WriteString(fmt.Sprintf("   Reason: %s\n", data.Error.Message))
+		}
+	}
+
+	output.WriteString(fmt.Sprintf("📱 App: %s (%s)\n", data.Application, data.AppID))
+	output.WriteString(fmt.Sprintf("👤 Type: %s\n", formatTokenType(data.Type)))
+	if data.UserID != "" {
+		output.WriteString(fmt.Sprintf("🆔 User ID: %s\n", data.UserID))
+	}
+	if data.IssuedAt > 0 {
+		output.WriteString(fmt.Sprintf("📅 Issued: %s\n", formatTokenTime(data.IssuedAt, loc)))
+	}
+	output.WriteString(fmt.Sprintf("⏳ Expires: %s\n", formatTokenTime(data.ExpiresAt, loc)))
+	output.WriteString(fmt.Sprintf("🗄️  Data Access Expires: %s\n", formatTokenTime(data.DataAccessExpiresAt, loc)))
+
+	output.WriteString("\n📜 Scopes:\n")
+	if len(data.Scopes) == 0 {
+		output.WriteString("   (none)\n")
+	}
+	for _, scope := range data.Scopes {
+		output.WriteString(fmt.Sprintf("   • %s\n", scope))
+	}
+
+	if len(data.GranularScopes) > 0 {
+		output.WriteString("\n🎯 Granular Scopes:\n")
+		for _, granular := range data.GranularScopes {
+			if len(granular.TargetIDs) == 0 {
+				output.WriteString(fmt.Sprintf("   • %s: all assets\n", granular.Scope))
+				continue
+			}
+			output.WriteString(fmt.Sprintf("   • %s: %s\n", granular.Scope, strings.Join(granular.TargetIDs, ", ")))
+		}
+	}
+
+	if len(warnings) > 0 {
+		output.WriteString("\n⚠️  Warnings:\n")
+		for _, warning := range warnings {
+			output.WriteString(fmt.Sprintf("   • %s\n", warning))
+		}
+	}
+
+	return output.String()
+}
+
+// formatTokenType maps debug_token types to readable labels
+func formatTokenType(tokenType string) string {
+	switch strings.ToUpper(tokenType) {
+	case "USER":
+		return "User"
+	case "SYSTEM_USER":
+		return "System User"
+	case "PAGE":
+		return "Page"
+	case "APP":
+		return "App"
+	case "":
+		return "Unknown"
+	default:
+		return strings.ToUpper(tokenType)
+	}
+}
+
+// formatTokenTime formats a token timestamp, where zero means it never expires
+func formatTokenTime(epoch int64, loc *time.Location) string {
+	if epoch == 0 {
+		return "never"
+	}
+	return datetime.ConvertEpochToLocal(epoch, loc).Format("2006-01-02 15:04:05 MST")
+}
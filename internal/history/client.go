@@ -0,0 +1,117 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"wppanalyticscli/internal/models"
+	"wppanalyticscli/internal/rollup"
+)
+
+// Client serves report requests from the local history instead of the Graph API.
+// It implements api.Client for the analytics endpoints; the store only holds
+// daily buckets, so finer granularities are not available.
+type Client struct {
+	store *Store
+	loc   *time.Location
+}
+
+// NewClient reads reports from store, rolling days up into months in loc
+func NewClient(store *Store, loc *time.Location) *Client {
+	return &Client{store: store, loc: loc}
+}
+
+// GetAnalytics returns the stored days in [start, end), rolled up for MONTH
+func (c *Client) GetAnalytics(ctx context.Context, wbaID string, start, end int64, granularity, accessToken string) (*models.AnalyticsResponse, error) {
+	points, err := c.store.Analytics(wbaID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToUpper(granularity) {
+	case "DAY", "DAILY":
+		granularity = "DAY"
+	case "MONTH":
+		points = rollup.Analytics(points, "MONTH", c.loc, time.Monday)
+	default:
+		return nil, fmt.Errorf("granularity %s is not available from local history, which stores daily data", granularity)
+	}
+
+	phoneNumbers, err := c.store.PhoneNumbers(wbaID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.AnalyticsResponse{ID: wbaID}
+	response.Analytics.PhoneNumbers = phoneNumbers
+	response.Analytics.Granularity = strings.ToUpper(granularity)
+	response.Analytics.DataPoints = points
+	return response, nil
+}
+
+// GetTemplateAnalytics returns the stored days of the templates in [start, end),
+// keeping only the requested metrics
+func (c *Client) GetTemplateAnalytics(ctx context.Context, wbaID string, start, end int64, granularity string, metricTypes []string, templateIDs []string, accessToken string) (*models.TemplateAnalyticsResponse, error) {
+	points, err := c.store.TemplatePoints(wbaID, templateIDs, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := make(map[string]bool)
+	for _, metric := range metricTypes {
+		metrics[strings.ToLower(strings.TrimSpace(metric))] = true
+	}
+	for i := range points {
+		points[i] = selectMetrics(points[i], metrics)
+	}
+
+	return &models.TemplateAnalyticsResponse{
+		Data: []models.TemplateAnalyticsData{
+			{Granularity: "DAILY", ProductType: "cloud_api", DataPoints: points},
+		},
+	}, nil
+}
+
+// ListTemplates is not stored locally
+func (c *Client) ListTemplates(ctx context.Context, wbaID string, accessToken string, limit int, after string) (*models.TemplateListResponse, error) {
+	return nil, fmt.Errorf("template lists are not available from local history")
+}
+
+// DebugToken is not available locally
+func (c *Client) DebugToken(ctx context.Context, accessToken string) (*models.TokenDebugResponse, error) {
+	return nil, fmt.Errorf("token checks are not available from local history")
+}
+
+// GetBusinessAccount returns the account details saved by the last sync
+func (c *Client) GetBusinessAccount(ctx context.Context, wbaID string, accessToken string) (*models.BusinessAccount, error) {
+	account, err := c.store.Account(wbaID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, fmt.Errorf("account details of %s are not in local history, run sync first", wbaID)
+	}
+	return account, nil
+}
+
+// selectMetrics clears the metrics of a point that were not requested, as the API does
+func selectMetrics(dp models.TemplateDataPoint, metrics map[string]bool) models.TemplateDataPoint {
+	if !metrics["sent"] {
+		dp.Sent = 0
+	}
+	if !metrics["delivered"] {
+		dp.Delivered = 0
+	}
+	if !metrics["read"] {
+		dp.Read = 0
+	}
+	if !metrics["clicked"] {
+		dp.Clicked = nil
+	}
+	if !metrics["cost"] {
+		dp.Cost = nil
+	}
+	return dp
+}
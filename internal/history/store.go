@@ -0,0 +1,281 @@
+package history
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"wppanalyticscli/internal/models"
+)
+
+// Top-level buckets. Each holds one nested bucket per WABA.
+var (
+	analyticsBucket = []byte("analytics") // start epoch -> DataPoint
+	templatesBucket = []byte("templates") // template ID + start epoch -> TemplateDataPoint
+	syncedBucket    = []byte("synced")    // series + day start epoch -> sync time
+	metaBucket      = []byte("meta")      // "phone_numbers" -> []string, "account" -> BusinessAccount
+)
+
+// Store is the local history of daily analytics, kept in an embedded bbolt database
+type Store struct {
+	db *bolt.DB
+}
+
+// DefaultPath returns the history database path under the user config directory
+func DefaultPath() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("could not locate the user config directory: %w", err)
+	}
+	return filepath.Join(base, "wppanalyticscli", "history.db"), nil
+}
+
+// Open opens or creates the history database at path
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history database %s: %w", path, err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// PutAnalytics stores daily analytics points for a WABA, replacing earlier values
+func (s *Store) PutAnalytics(wbaID string, points []models.DataPoint) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := wabaBucket(tx, analyticsBucket, wbaID)
+		if err != nil {
+			return err
+		}
+		for _, dp := range points {
+			if err := putJSON(bucket, epochKey("", dp.Start), dp); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Analytics returns the stored points of a WABA starting in [start, end), in order
+func (s *Store) Analytics(wbaID string, start, end int64) ([]models.DataPoint, error) {
+	var points []models.DataPoint
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := existingBucket(tx, analyticsBucket, wbaID)
+		if bucket == nil {
+			return nil
+		}
+		c := bucket.Cursor()
+		for k, v := c.Seek(epochKey("", start)); k != nil && keyEpoch(k) < end; k, v = c.Next() {
+			var dp models.DataPoint
+			if err := json.Unmarshal(v, &dp); err != nil {
+				return fmt.Errorf("corrupt analytics entry: %w", err)
+			}
+			points = append(points, dp)
+		}
+		return nil
+	})
+	return points, err
+}
+
+// PutTemplatePoints stores daily template points for a WABA, replacing earlier values
+func (s *Store) PutTemplatePoints(wbaID string, points []models.TemplateDataPoint) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := wabaBucket(tx, templatesBucket, wbaID)
+		if err != nil {
+			return err
+		}
+		for _, dp := range points {
+			if err := putJSON(bucket, epochKey(dp.TemplateID+"/", dp.Start), dp); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// TemplatePoints returns the stored points of the given templates starting in
+// [start, end), ordered by template and date
+func (s *Store) TemplatePoints(wbaID string, templateIDs []string, start, end int64) ([]models.TemplateDataPoint, error) {
+	var points []models.TemplateDataPoint
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := existingBucket(tx, templatesBucket, wbaID)
+		if bucket == nil {
+			return nil
+		}
+		for _, templateID := range templateIDs {
+			prefix := templateID + "/"
+			c := bucket.Cursor()
+			for k, v := c.Seek(epochKey(prefix, start)); k != nil && len(k) == len(prefix)+8 && string(k[:len(prefix)]) == prefix && keyEpoch(k) < end; k, v = c.Next() {
+				var dp models.TemplateDataPoint
+				if err := json.Unmarshal(v, &dp); err != nil {
+					return fmt.Errorf("corrupt template entry: %w", err)
+				}
+				points = append(points, dp)
+			}
+		}
+		return nil
+	})
+	return points, err
+}
+
+// MarkSynced records that the days starting at days were fetched for a series,
+// such as "analytics" or "template/<id>"
+func (s *Store) MarkSynced(wbaID, series string, days []int64, at time.Time) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := wabaBucket(tx, syncedBucket, wbaID)
+		if err != nil {
+			return err
+		}
+		for _, day := range days {
+			if err := putJSON(bucket, epochKey(series+"/", day), at.Unix()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SyncedDays returns the set of days already fetched for a series
+func (s *Store) SyncedDays(wbaID, series string) (map[int64]bool, error) {
+	days := make(map[int64]bool)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := existingBucket(tx, syncedBucket, wbaID)
+		if bucket == nil {
+			return nil
+		}
+		prefix := []byte(series + "/")
+		c := bucket.Cursor()
+		for k, _ := c.Seek(prefix); k != nil && len(k) == len(prefix)+8 && string(k[:len(prefix)]) == string(prefix); k, _ = c.Next() {
+			days[keyEpoch(k)] = true
+		}
+		return nil
+	})
+	return days, err
+}
+
+// LastSynced returns the start of the latest synced day of a series, or false when
+// the series was never synced
+func (s *Store) LastSynced(wbaID, series string) (int64, bool, error) {
+	days, err := s.SyncedDays(wbaID, series)
+	if err != nil || len(days) == 0 {
+		return 0, false, err
+	}
+	var last int64
+	for day := range days {
+		if day > last {
+			last = day
+		}
+	}
+	return last, true, nil
+}
+
+// SetPhoneNumbers stores the phone numbers reported for a WABA
+func (s *Store) SetPhoneNumbers(wbaID string, phoneNumbers []string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := wabaBucket(tx, metaBucket, wbaID)
+		if err != nil {
+			return err
+		}
+		return putJSON(bucket, []byte("phone_numbers"), phoneNumbers)
+	})
+}
+
+// PhoneNumbers returns the stored phone numbers of a WABA
+func (s *Store) PhoneNumbers(wbaID string) ([]string, error) {
+	var phoneNumbers []string
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := existingBucket(tx, metaBucket, wbaID)
+		if bucket == nil {
+			return nil
+		}
+		if v := bucket.Get([]byte("phone_numbers")); v != nil {
+			return json.Unmarshal(v, &phoneNumbers)
+		}
+		return nil
+	})
+	return phoneNumbers, err
+}
+
+// SetAccount stores the account details of a WABA, used for its currency
+func (s *Store) SetAccount(account models.BusinessAccount) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := wabaBucket(tx, metaBucket, account.ID)
+		if err != nil {
+			return err
+		}
+		return putJSON(bucket, []byte("account"), account)
+	})
+}
+
+// Account returns the stored account details of a WABA, or nil if never synced
+func (s *Store) Account(wbaID string) (*models.BusinessAccount, error) {
+	var account *models.BusinessAccount
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := existingBucket(tx, metaBucket, wbaID)
+		if bucket == nil {
+			return nil
+		}
+		if v := bucket.Get([]byte("account")); v != nil {
+			return json.Unmarshal(v, &account)
+		}
+		return nil
+	})
+	return account, err
+}
+
+// wabaBucket returns the nested bucket of a WABA, creating it if needed
+func wabaBucket(tx *bolt.Tx, name []byte, wbaID string) (*bolt.Bucket, error) {
+	root, err := tx.CreateBucketIfNotExists(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s bucket: %w", name, err)
+	}
+	bucket, err := root.CreateBucketIfNotExists([]byte(wbaID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s bucket for %s: %w", name, wbaID, err)
+	}
+	return bucket, nil
+}
+
+// existingBucket returns the nested bucket of a WABA, or nil if nothing was stored
+func existingBucket(tx *bolt.Tx, name []byte, wbaID string) *bolt.Bucket {
+	root := tx.Bucket(name)
+	if root == nil {
+		return nil
+	}
+	return root.Bucket([]byte(wbaID))
+}
+
+// putJSON stores value encoded as JSON
+func putJSON(bucket *bolt.Bucket, key []byte, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode history entry: %w", err)
+	}
+	return bucket.Put(key, data)
+}
+
+// epochKey appends a big-endian epoch to prefix, so keys sort chronologically
+func epochKey(prefix string, epoch int64) []byte {
+	key := make([]byte, len(prefix)+8)
+	copy(key, prefix)
+	binary.BigEndian.PutUint64(key[len(prefix):], uint64(epoch))
+	return key
+}
+
+// keyEpoch reads the epoch at the end of a key
+func keyEpoch(key []byte) int64 {
+	return int64(binary.BigEndian.Uint64(key[len(key)-8:]))
+}
@@ -0,0 +1,129 @@
+package history
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"wppanalyticscli/internal/models"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	store, err := Open(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestStore_AnalyticsRoundTrip(t *testing.T) {
+	store := openTestStore(t)
+
+	points := []models.DataPoint{
+		{Start: 1750474800, End: 1750561200, Sent: 20, Delivered: 18},
+		{Start: 1750388400, End: 1750474800, Sent: 10, Delivered: 9},
+	}
+	if err := store.PutAnalytics("123", points); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// A second write of the same day replaces the first
+	if err := store.PutAnalytics("123", []models.DataPoint{{Start: 1750474800, End: 1750561200, Sent: 25, Delivered: 24}}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	got, err := store.Analytics("123", 1750388400, 1750561200)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 points, got %d", len(got))
+	}
+	if got[0].Start != 1750388400 || got[1].Sent != 25 {
+		t.Errorf("Expected chronological points with the latest values, got %+v", got)
+	}
+
+	got, err = store.Analytics("123", 1750474800, 1750561200)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].Start != 1750474800 {
+		t.Errorf("Expected only the point inside the window, got %+v", got)
+	}
+
+	got, err = store.Analytics("999", 0, 1750561200)
+	if err != nil || len(got) != 0 {
+		t.Errorf("Expected no points for an unknown WABA, got %+v (err %v)", got, err)
+	}
+}
+
+func TestStore_TemplatePointsByTemplate(t *testing.T) {
+	store := openTestStore(t)
+
+	points := []models.TemplateDataPoint{
+		{TemplateID: "1", Start: 1750388400, End: 1750474800, Sent: 5},
+		{TemplateID: "1", Start: 1750474800, End: 1750561200, Sent: 6},
+		{TemplateID: "12", Start: 1750388400, End: 1750474800, Sent: 7},
+	}
+	if err := store.PutTemplatePoints("123", points); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	got, err := store.TemplatePoints("123", []string{"1"}, 0, 1750561200)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 points of template 1, got %+v", got)
+	}
+	for _, dp := range got {
+		if dp.TemplateID != "1" {
+			t.Errorf("Expected only template 1, got %s", dp.TemplateID)
+		}
+	}
+}
+
+func TestStore_SyncedDays(t *testing.T) {
+	store := openTestStore(t)
+
+	if _, ok, err := store.LastSynced("123", "analytics"); err != nil || ok {
+		t.Fatalf("Expected no synced days, got ok=%v err=%v", ok, err)
+	}
+
+	at := time.Unix(1750600000, 0)
+	if err := store.MarkSynced("123", "analytics", []int64{1750388400, 1750474800}, at); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := store.MarkSynced("123", "template/1", []int64{1750561200}, at); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	days, err := store.SyncedDays("123", "analytics")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(days) != 2 || !days[1750388400] || !days[1750474800] {
+		t.Errorf("Expected the 2 analytics days, got %v", days)
+	}
+
+	last, ok, err := store.LastSynced("123", "analytics")
+	if err != nil || !ok || last != 1750474800 {
+		t.Errorf("Expected last synced day 1750474800, got %d (ok=%v err=%v)", last, ok, err)
+	}
+}
+
+func TestStore_PhoneNumbers(t *testing.T) {
+	store := openTestStore(t)
+
+	if err := store.SetPhoneNumbers("123", []string{"5511999999999"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	phoneNumbers, err := store.PhoneNumbers("123")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(phoneNumbers) != 1 || phoneNumbers[0] != "5511999999999" {
+		t.Errorf("Expected the stored phone number, got %v", phoneNumbers)
+	}
+}
@@ -0,0 +1,243 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"wppanalyticscli/internal/api"
+	"wppanalyticscli/internal/models"
+)
+
+// maxSyncDays is the longest window requested from the API at once
+const maxSyncDays = 30
+
+// templateMetrics are the metrics stored for every template day
+var templateMetrics = []string{"sent", "delivered", "read", "clicked", "cost"}
+
+// SyncOptions selects what Sync fetches
+type SyncOptions struct {
+	WBAID       string
+	AccessToken string
+	TemplateIDs []string       // templates to sync; all templates of the WABA when empty
+	Since       time.Time      // first day to backfill
+	Trailing    int            // recent days fetched again on every sync, as counts still change
+	Location    *time.Location // timezone of day boundaries
+}
+
+// SyncResult reports what a sync fetched
+type SyncResult struct {
+	AnalyticsDays int
+	Templates     int
+	TemplateDays  int
+	Requests      int
+}
+
+// Syncer copies daily analytics from the API into a Store
+type Syncer struct {
+	client   api.Client
+	store    *Store
+	progress io.Writer
+	now      func() time.Time
+}
+
+// NewSyncer creates a syncer reading from client into store
+func NewSyncer(client api.Client, store *Store) *Syncer {
+	return &Syncer{client: client, store: store, now: time.Now}
+}
+
+// SetProgress writes a line per API request to w
+func (s *Syncer) SetProgress(w io.Writer) {
+	s.progress = w
+}
+
+// Sync fetches every day from Since to yesterday that is missing from the store,
+// plus the trailing days, for the WABA analytics and each template. Each run is
+// stored as it arrives, so a cancelled sync keeps the days already fetched.
+func (s *Syncer) Sync(ctx context.Context, opts SyncOptions) (SyncResult, error) {
+	var result SyncResult
+
+	loc := opts.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	today := midnight(s.now(), loc)
+	first := midnight(opts.Since, loc)
+
+	// The currency of costs comes from the account, so keep its details current
+	s.logf("account\n")
+	account, err := s.client.GetBusinessAccount(ctx, opts.WBAID, opts.AccessToken)
+	if err != nil {
+		return result, fmt.Errorf("syncing account details: %w", err)
+	}
+	result.Requests++
+	if err := s.store.SetAccount(*account); err != nil {
+		return result, err
+	}
+
+	days, err := s.pendingDays(opts.WBAID, "analytics", first, today, opts.Trailing)
+	if err != nil {
+		return result, err
+	}
+	for _, run := range chunkRuns(days) {
+		if err := s.syncAnalytics(ctx, opts, run); err != nil {
+			return result, err
+		}
+		result.Requests++
+		result.AnalyticsDays += len(run)
+	}
+
+	templateIDs := opts.TemplateIDs
+	if len(templateIDs) == 0 {
+		templateIDs, err = s.allTemplateIDs(ctx, opts)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	for _, templateID := range templateIDs {
+		series := "template/" + templateID
+		days, err := s.pendingDays(opts.WBAID, series, first, today, opts.Trailing)
+		if err != nil {
+			return result, err
+		}
+		if len(days) > 0 {
+			result.Templates++
+		}
+		for _, run := range chunkRuns(days) {
+			if err := s.syncTemplate(ctx, opts, templateID, run); err != nil {
+				return result, err
+			}
+			result.Requests++
+			result.TemplateDays += len(run)
+		}
+	}
+
+	return result, nil
+}
+
+// syncAnalytics fetches and stores one run of consecutive days of WABA analytics
+func (s *Syncer) syncAnalytics(ctx context.Context, opts SyncOptions, run []time.Time) error {
+	start, end := run[0], run[len(run)-1].AddDate(0, 0, 1)
+	s.logf("analytics %s..%s\n", start.Format("2006-01-02"), end.Format("2006-01-02"))
+
+	response, err := s.client.GetAnalytics(ctx, opts.WBAID, start.Unix(), end.Unix(), "DAY", opts.AccessToken)
+	if err != nil {
+		return fmt.Errorf("syncing analytics from %s: %w", start.Format("2006-01-02"), err)
+	}
+
+	if err := s.store.PutAnalytics(opts.WBAID, response.Analytics.DataPoints); err != nil {
+		return err
+	}
+	if len(response.Analytics.PhoneNumbers) > 0 {
+		if err := s.store.SetPhoneNumbers(opts.WBAID, response.Analytics.PhoneNumbers); err != nil {
+			return err
+		}
+	}
+	return s.store.MarkSynced(opts.WBAID, "analytics", epochs(run), s.now())
+}
+
+// syncTemplate fetches and stores one run of consecutive days of a template's analytics
+func (s *Syncer) syncTemplate(ctx context.Context, opts SyncOptions, templateID string, run []time.Time) error {
+	start, end := run[0], run[len(run)-1].AddDate(0, 0, 1)
+	s.logf("template %s %s..%s\n", templateID, start.Format("2006-01-02"), end.Format("2006-01-02"))
+
+	response, err := s.client.GetTemplateAnalytics(ctx, opts.WBAID, start.Unix(), end.Unix(), "daily", templateMetrics, []string{templateID}, opts.AccessToken)
+	if err != nil {
+		return fmt.Errorf("syncing template %s from %s: %w", templateID, start.Format("2006-01-02"), err)
+	}
+
+	var points []models.TemplateDataPoint
+	for _, data := range response.Data {
+		points = append(points, data.DataPoints...)
+	}
+	if err := s.store.PutTemplatePoints(opts.WBAID, points); err != nil {
+		return err
+	}
+	return s.store.MarkSynced(opts.WBAID, "template/"+templateID, epochs(run), s.now())
+}
+
+// allTemplateIDs lists every template of the WABA, following pagination
+func (s *Syncer) allTemplateIDs(ctx context.Context, opts SyncOptions) ([]string, error) {
+	const pageSize = 100
+
+	var ids []string
+	after := ""
+	for {
+		s.logf("templates page %q\n", after)
+		page, err := s.client.ListTemplates(ctx, opts.WBAID, opts.AccessToken, pageSize, after)
+		if err != nil {
+			return nil, fmt.Errorf("listing templates: %w", err)
+		}
+		for _, template := range page.Data {
+			ids = append(ids, template.ID)
+		}
+
+		next := ""
+		if page.Paging != nil && page.Paging.Cursors != nil {
+			next = page.Paging.Cursors.After
+		}
+		if len(page.Data) < pageSize || next == "" || next == after {
+			return ids, nil
+		}
+		after = next
+	}
+}
+
+// pendingDays returns the days in [first, today) never synced for a series, plus
+// the trailing days before today, oldest first
+func (s *Syncer) pendingDays(wbaID, series string, first, today time.Time, trailing int) ([]time.Time, error) {
+	synced, err := s.store.SyncedDays(wbaID, series)
+	if err != nil {
+		return nil, err
+	}
+
+	refetchFrom := today.AddDate(0, 0, -trailing)
+	var days []time.Time
+	for day := first; day.Before(today); day = day.AddDate(0, 0, 1) {
+		if !synced[day.Unix()] || !day.Before(refetchFrom) {
+			days = append(days, day)
+		}
+	}
+	return days, nil
+}
+
+// logf writes a progress line when progress output is enabled
+func (s *Syncer) logf(format string, args ...interface{}) {
+	if s.progress != nil {
+		fmt.Fprintf(s.progress, "Sync: "+format, args...)
+	}
+}
+
+// chunkRuns splits days into runs of consecutive days of at most maxSyncDays
+func chunkRuns(days []time.Time) [][]time.Time {
+	var runs [][]time.Time
+	for _, day := range days {
+		n := len(runs)
+		if n > 0 {
+			last := runs[n-1]
+			if len(last) < maxSyncDays && last[len(last)-1].AddDate(0, 0, 1).Equal(day) {
+				runs[n-1] = append(last, day)
+				continue
+			}
+		}
+		runs = append(runs, []time.Time{day})
+	}
+	return runs
+}
+
+// midnight returns the start of the day of t in loc
+func midnight(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// epochs converts days to Unix epochs
+func epochs(days []time.Time) []int64 {
+	values := make([]int64, len(days))
+	for i, day := range days {
+		values[i] = day.Unix()
+	}
+	return values
+}
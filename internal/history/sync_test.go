@@ -0,0 +1,216 @@
+package history
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"wppanalyticscli/internal/models"
+)
+
+// fakeClient returns one point per day of every requested window and records the windows
+type fakeClient struct {
+	analytics [][2]int64
+	templates []string
+	lists     int
+}
+
+func (f *fakeClient) GetAnalytics(ctx context.Context, wbaID string, start, end int64, granularity, accessToken string) (*models.AnalyticsResponse, error) {
+	f.analytics = append(f.analytics, [2]int64{start, end})
+	response := &models.AnalyticsResponse{ID: wbaID}
+	response.Analytics.Granularity = granularity
+	response.Analytics.PhoneNumbers = []string{"5511999999999"}
+	for day := start; day < end; day += 86400 {
+		response.Analytics.DataPoints = append(response.Analytics.DataPoints, models.DataPoint{Start: day, End: day + 86400, Sent: 10, Delivered: 9})
+	}
+	return response, nil
+}
+
+func (f *fakeClient) GetTemplateAnalytics(ctx context.Context, wbaID string, start, end int64, granularity string, metricTypes []string, templateIDs []string, accessToken string) (*models.TemplateAnalyticsResponse, error) {
+	f.templates = append(f.templates, templateIDs[0])
+	var points []models.TemplateDataPoint
+	for day := start; day < end; day += 86400 {
+		points = append(points, models.TemplateDataPoint{TemplateID: templateIDs[0], Start: day, End: day + 86400, Sent: 4, Delivered: 3, Read: 2, Cost: []models.CostMetric{{Type: "amount_spent", Value: 0.05}}})
+	}
+	return &models.TemplateAnalyticsResponse{Data: []models.TemplateAnalyticsData{{Granularity: "DAILY", DataPoints: points}}}, nil
+}
+
+func (f *fakeClient) ListTemplates(ctx context.Context, wbaID string, accessToken string, limit int, after string) (*models.TemplateListResponse, error) {
+	f.lists++
+	return &models.TemplateListResponse{Data: []models.MessageTemplate{{ID: "1"}, {ID: "2"}}}, nil
+}
+
+func (f *fakeClient) DebugToken(ctx context.Context, accessToken string) (*models.TokenDebugResponse, error) {
+	return &models.TokenDebugResponse{}, nil
+}
+
+func (f *fakeClient) GetBusinessAccount(ctx context.Context, wbaID string, accessToken string) (*models.BusinessAccount, error) {
+	return &models.BusinessAccount{ID: wbaID, Currency: "BRL"}, nil
+}
+
+func newTestSyncer(t *testing.T, now time.Time) (*Syncer, *fakeClient, *Store) {
+	t.Helper()
+	fake := &fakeClient{}
+	store := openTestStore(t)
+	syncer := NewSyncer(fake, store)
+	syncer.now = func() time.Time { return now }
+	return syncer, fake, store
+}
+
+func TestSync_BackfillThenIncremental(t *testing.T) {
+	now := time.Date(2025, 6, 25, 15, 0, 0, 0, time.UTC)
+	syncer, fake, store := newTestSyncer(t, now)
+	opts := SyncOptions{WBAID: "123", TemplateIDs: []string{"1"}, Since: now.AddDate(0, 0, -45), Trailing: 3, Location: time.UTC}
+
+	result, err := syncer.Sync(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.AnalyticsDays != 45 || result.TemplateDays != 45 || result.Templates != 1 {
+		t.Errorf("Expected 45 analytics and template days, got %+v", result)
+	}
+	// 45 days need two requests of at most 30 days per series, plus the account
+	if result.Requests != 5 || len(fake.analytics) != 2 {
+		t.Errorf("Expected 5 requests, got %+v", result)
+	}
+	if fake.lists != 0 {
+		t.Errorf("Expected no template listing when templates are given, got %d", fake.lists)
+	}
+
+	today := time.Date(2025, 6, 25, 0, 0, 0, 0, time.UTC)
+	points, err := store.Analytics("123", 0, today.Unix())
+	if err != nil || len(points) != 45 {
+		t.Fatalf("Expected 45 stored days, got %d (err %v)", len(points), err)
+	}
+	if last := fake.analytics[len(fake.analytics)-1][1]; last != today.Unix() {
+		t.Errorf("Expected sync to stop at today's midnight, got %d", last)
+	}
+
+	fake.analytics = nil
+	result, err = syncer.Sync(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.AnalyticsDays != 3 || result.TemplateDays != 3 || result.Requests != 3 {
+		t.Errorf("Expected only the 3 trailing days fetched again, got %+v", result)
+	}
+	if fake.analytics[0][0] != today.AddDate(0, 0, -3).Unix() {
+		t.Errorf("Expected refetch from 3 days ago, got %d", fake.analytics[0][0])
+	}
+}
+
+func TestSync_FillsGaps(t *testing.T) {
+	now := time.Date(2025, 6, 25, 15, 0, 0, 0, time.UTC)
+	syncer, fake, store := newTestSyncer(t, now)
+	today := time.Date(2025, 6, 25, 0, 0, 0, 0, time.UTC)
+
+	// Days 10 and 9 before today are missing from an otherwise complete history
+	var synced []int64
+	for i := 20; i > 0; i-- {
+		if i != 10 && i != 9 {
+			synced = append(synced, today.AddDate(0, 0, -i).Unix())
+		}
+	}
+	if err := store.MarkSynced("123", "analytics", synced, now); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	result, err := syncer.Sync(context.Background(), SyncOptions{WBAID: "123", TemplateIDs: []string{"1"}, Since: today.AddDate(0, 0, -20), Trailing: 0, Location: time.UTC})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.AnalyticsDays != 2 || len(fake.analytics) != 1 {
+		t.Fatalf("Expected one request for the 2 missing days, got %+v", result)
+	}
+	if fake.analytics[0] != [2]int64{today.AddDate(0, 0, -10).Unix(), today.AddDate(0, 0, -8).Unix()} {
+		t.Errorf("Expected the gap window, got %v", fake.analytics[0])
+	}
+}
+
+func TestSync_ListsTemplatesWhenNoneGiven(t *testing.T) {
+	now := time.Date(2025, 6, 25, 15, 0, 0, 0, time.UTC)
+	syncer, fake, _ := newTestSyncer(t, now)
+
+	var progress strings.Builder
+	syncer.SetProgress(&progress)
+	result, err := syncer.Sync(context.Background(), SyncOptions{WBAID: "123", Since: now.AddDate(0, 0, -2), Trailing: 1, Location: time.UTC})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fake.lists != 1 || result.Templates != 2 {
+		t.Errorf("Expected both listed templates synced, got %+v", result)
+	}
+	if strings.Join(fake.templates, ",") != "1,2" {
+		t.Errorf("Expected template requests for 1 and 2, got %v", fake.templates)
+	}
+	if !strings.Contains(progress.String(), "Sync: analytics") {
+		t.Errorf("Expected progress lines, got %q", progress.String())
+	}
+}
+
+func TestChunkRuns(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var days []time.Time
+	for i := 0; i < 35; i++ {
+		days = append(days, start.AddDate(0, 0, i))
+	}
+	days = append(days, start.AddDate(0, 0, 40))
+
+	runs := chunkRuns(days)
+	if len(runs) != 3 {
+		t.Fatalf("Expected 3 runs, got %d", len(runs))
+	}
+	if len(runs[0]) != maxSyncDays || len(runs[1]) != 5 || len(runs[2]) != 1 {
+		t.Errorf("Expected runs of 30, 5 and 1 days, got %d, %d and %d", len(runs[0]), len(runs[1]), len(runs[2]))
+	}
+}
+
+func TestClient_ReadsLocalHistory(t *testing.T) {
+	now := time.Date(2025, 6, 25, 15, 0, 0, 0, time.UTC)
+	syncer, _, store := newTestSyncer(t, now)
+	if _, err := syncer.Sync(context.Background(), SyncOptions{WBAID: "123", TemplateIDs: []string{"1"}, Since: time.Date(2025, 5, 20, 0, 0, 0, 0, time.UTC), Location: time.UTC}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	client := NewClient(store, time.UTC)
+	start, end := time.Date(2025, 5, 20, 0, 0, 0, 0, time.UTC).Unix(), time.Date(2025, 6, 25, 0, 0, 0, 0, time.UTC).Unix()
+
+	monthly, err := client.GetAnalytics(context.Background(), "123", start, end, "MONTH", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(monthly.Analytics.DataPoints) != 2 {
+		t.Fatalf("Expected May and June buckets, got %d", len(monthly.Analytics.DataPoints))
+	}
+	if monthly.Analytics.DataPoints[0].Sent != 120 {
+		t.Errorf("Expected 12 May days of 10 sent, got %d", monthly.Analytics.DataPoints[0].Sent)
+	}
+	if len(monthly.Analytics.PhoneNumbers) != 1 {
+		t.Errorf("Expected stored phone numbers, got %v", monthly.Analytics.PhoneNumbers)
+	}
+
+	if _, err := client.GetAnalytics(context.Background(), "123", start, end, "HOUR", ""); err == nil {
+		t.Error("Expected error for hourly granularity")
+	}
+
+	templates, err := client.GetTemplateAnalytics(context.Background(), "123", start, end, "DAILY", []string{"sent", "read"}, []string{"1"}, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	points := templates.Data[0].DataPoints
+	if len(points) != 36 {
+		t.Fatalf("Expected 36 template days, got %d", len(points))
+	}
+	if points[0].Sent != 4 || points[0].Read != 2 || points[0].Delivered != 0 || points[0].Cost != nil {
+		t.Errorf("Expected only sent and read metrics, got %+v", points[0])
+	}
+
+	account, err := client.GetBusinessAccount(context.Background(), "123", "")
+	if err != nil || account.Currency != "BRL" {
+		t.Errorf("Expected the synced account currency, got %+v (err %v)", account, err)
+	}
+
+	if _, err := client.ListTemplates(context.Background(), "123", "", 10, ""); err == nil {
+		t.Error("Expected error listing templates from local history")
+	}
+}
@@ -0,0 +1,10 @@
+package models
+
+// BusinessAccount represents the WhatsApp Business Account fields used by reports
+type BusinessAccount struct {
+	ID                       string `json:"id"`
+	Name                     string `json:"name,omitempty"`
+	Currency                 string `json:"currency,omitempty"`
+	TimezoneID               string `json:"timezone_id,omitempty"`
+	MessageTemplateNamespace string `json:"message_template_namespace,omitempty"`
+}
@@ -0,0 +1,34 @@
+package models
+
+// TokenDebugResponse represents the response from the debug_token endpoint
+type TokenDebugResponse struct {
+	Data TokenDebugData `json:"data"`
+}
+
+// TokenDebugData holds the metadata Facebook reports for an access token
+type TokenDebugData struct {
+	AppID               string           `json:"app_id"`
+	Type                string           `json:"type"`
+	Application         string           `json:"application"`
+	DataAccessExpiresAt int64            `json:"data_access_expires_at"`
+	ExpiresAt           int64            `json:"expires_at"`
+	IsValid             bool             `json:"is_valid"`
+	IssuedAt            int64            `json:"issued_at,omitempty"`
+	Scopes              []string         `json:"scopes"`
+	GranularScopes      []GranularScope  `json:"granular_scopes,omitempty"`
+	UserID              string           `json:"user_id,omitempty"`
+	Error               *TokenDebugError `json:"error,omitempty"`
+}
+
+// GranularScope represents a permission restricted to specific targets (e.g. WABA IDs)
+type GranularScope struct {
+	Scope     string   `json:"scope"`
+	TargetIDs []string `json:"target_ids,omitempty"`
+}
+
+// TokenDebugError describes why a token is no longer valid
+type TokenDebugError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Subcode int    `json:"subcode,omitempty"`
+}
@@ -0,0 +1,220 @@
+package rollup
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"wppanalyticscli/internal/datetime"
+	"wppanalyticscli/internal/models"
+)
+
+// IsClientSide reports whether the granularity is computed locally rather than by the API
+func IsClientSide(granularity string) bool {
+	switch strings.ToUpper(granularity) {
+	case "HOUR", "WEEK", "QUARTER", "YEAR":
+		return true
+	default:
+		return false
+	}
+}
+
+// IsTemplateClientSide reports whether a template granularity needs a local rollup,
+// since the template analytics endpoint only returns daily data
+func IsTemplateClientSide(granularity string) bool {
+	return IsClientSide(granularity) || strings.ToUpper(granularity) == "MONTH"
+}
+
+// SourceGranularity returns the finest API granularity that can be rolled up
+// into the requested one for the given mode
+func SourceGranularity(mode, granularity string) string {
+	if mode == "template" {
+		return "daily"
+	}
+
+	switch strings.ToUpper(granularity) {
+	case "HOUR":
+		return "HALF_HOUR"
+	case "WEEK", "QUARTER", "YEAR":
+		return "DAY"
+	default:
+		return granularity
+	}
+}
+
+// Analytics re-buckets data points into the given granularity, grouping each
+// point by its start time in loc. Bucket ranges cover only the points they contain.
+func Analytics(points []models.DataPoint, granularity string, loc *time.Location, weekStart time.Weekday) []models.DataPoint {
+	var result []models.DataPoint
+	index := make(map[int64]int)
+
+	for _, dp := range points {
+		bucket := bucketKey(dp.Start, granularity, loc, weekStart)
+
+		i, ok := index[bucket]
+		if !ok {
+			index[bucket] = len(result)
+			result = append(result, models.DataPoint{Start: dp.Start, End: dp.End})
+			i = len(result) - 1
+		}
+
+		merged := &result[i]
+		merged.Sent += dp.Sent
+		merged.Delivered += dp.Delivered
+		if dp.Start < merged.Start {
+			merged.Start = dp.Start
+		}
+		if dp.End > merged.End {
+			merged.End = dp.End
+		}
+	}
+
+	sort.SliceStable(result, func(a, b int) bool {
+		return result[a].Start < result[b].Start
+	})
+
+	return result
+}
+
+// Templates re-buckets template data points into the given granularity, per template.
+// Counts, clicks and amount spent are summed; unit costs are recomputed from the
+// totals and other cost types are averaged, see bucketCosts.rollup.
+func Templates(points []models.TemplateDataPoint, granularity string, loc *time.Location, weekStart time.Weekday) []models.TemplateDataPoint {
+	type key struct {
+		templateID string
+		bucket     int64
+	}
+
+	var result []models.TemplateDataPoint
+	var costs []*bucketCosts
+	index := make(map[key]int)
+
+	for _, dp := range points {
+		k := key{templateID: dp.TemplateID, bucket: bucketKey(dp.Start, granularity, loc, weekStart)}
+
+		i, ok := index[k]
+		if !ok {
+			index[k] = len(result)
+			result = append(result, models.TemplateDataPoint{TemplateID: dp.TemplateID, Start: dp.Start, End: dp.End})
+			costs = append(costs, &bucketCosts{})
+			i = len(result) - 1
+		}
+
+		merged := &result[i]
+		merged.Sent += dp.Sent
+		merged.Delivered += dp.Delivered
+		merged.Read += dp.Read
+		merged.Clicked = mergeClicks(merged.Clicked, dp.Clicked)
+		costs[i].add(dp.Cost)
+		if dp.Start < merged.Start {
+			merged.Start = dp.Start
+		}
+		if dp.End > merged.End {
+			merged.End = dp.End
+		}
+	}
+
+	for i := range result {
+		result[i].Cost = costs[i].rollup(result[i])
+	}
+
+	sort.SliceStable(result, func(a, b int) bool {
+		return result[a].Start < result[b].Start
+	})
+
+	return result
+}
+
+// bucketKey returns the bucket start epoch for a point starting at epoch
+func bucketKey(epoch int64, granularity string, loc *time.Location, weekStart time.Weekday) int64 {
+	t := datetime.ConvertEpochToLocal(epoch, loc)
+	return datetime.TruncateToGranularity(t, granularity, weekStart).Unix()
+}
+
+// mergeClicks adds clicks into existing, matching actions by type and button content
+func mergeClicks(existing, clicks []models.ClickedAction) []models.ClickedAction {
+	for _, clicked := range clicks {
+		found := false
+		for i := range existing {
+			if existing[i].Type == clicked.Type && existing[i].ButtonContent == clicked.ButtonContent {
+				existing[i].Count += clicked.Count
+				found = true
+				break
+			}
+		}
+		if !found {
+			existing = append(existing, clicked)
+		}
+	}
+	return existing
+}
+
+// unitCostCounts maps every unit cost type the reports know to the count it is
+// per; rolled up unit costs are the summed amount_spent divided by that count
+var unitCostCounts = map[string]func(dp models.TemplateDataPoint) int{
+	"cost_per_delivered": func(dp models.TemplateDataPoint) int { return dp.Delivered },
+	"cost_per_read":      func(dp models.TemplateDataPoint) int { return dp.Read },
+	"cost_per_click": func(dp models.TemplateDataPoint) int {
+		return countClicks(dp.Clicked, "")
+	},
+	"cost_per_url_button_click": func(dp models.TemplateDataPoint) int {
+		return countClicks(dp.Clicked, "url_button")
+	},
+}
+
+// bucketCosts accumulates the cost metrics of the points in one bucket
+type bucketCosts struct {
+	types  []string // Cost types in order of first appearance
+	sums   map[string]float64
+	counts map[string]int // Points reporting each type
+}
+
+// add records the cost metrics of one point
+func (b *bucketCosts) add(costs []models.CostMetric) {
+	if b.sums == nil {
+		b.sums = make(map[string]float64)
+		b.counts = make(map[string]int)
+	}
+	for _, cost := range costs {
+		if _, seen := b.counts[cost.Type]; !seen {
+			b.types = append(b.types, cost.Type)
+		}
+		b.sums[cost.Type] += cost.Value
+		b.counts[cost.Type]++
+	}
+}
+
+// rollup returns the cost metrics of the rolled up point dp, keeping every type
+// the API reported: amount_spent is summed, the unit costs in unitCostCounts are
+// recomputed from the totals and any other type is averaged over the points
+// reporting it, as the report does for unknown cost types
+func (b *bucketCosts) rollup(dp models.TemplateDataPoint) []models.CostMetric {
+	var costs []models.CostMetric
+	for _, costType := range b.types {
+		value := b.sums[costType]
+		count, isUnitCost := unitCostCounts[costType]
+		switch {
+		case costType == "amount_spent":
+		case isUnitCost:
+			value = 0
+			if n := count(dp); n > 0 {
+				value = b.sums["amount_spent"] / float64(n)
+			}
+		default:
+			value /= float64(b.counts[costType])
+		}
+		costs = append(costs, models.CostMetric{Type: costType, Value: value})
+	}
+	return costs
+}
+
+// countClicks adds up the clicks of the given button type, or of every type when empty
+func countClicks(clicks []models.ClickedAction, buttonType string) int {
+	total := 0
+	for _, clicked := range clicks {
+		if buttonType == "" || clicked.Type == buttonType {
+			total += clicked.Count
+		}
+	}
+	return total
+}
@@ -0,0 +1,172 @@
+package rollup
+
+import (
+	"testing"
+	"time"
+
+	"wppanalyticscli/internal/models"
+)
+
+func dailyPoints(loc *time.Location, from time.Time, days int) []models.DataPoint {
+	var points []models.DataPoint
+	for i := 0; i < days; i++ {
+		start := from.AddDate(0, 0, i)
+		points = append(points, models.DataPoint{
+			Start:     start.Unix(),
+			End:       start.AddDate(0, 0, 1).Unix(),
+			Sent:      10,
+			Delivered: 9,
+		})
+	}
+	return points
+}
+
+func TestAnalytics_Week(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Sao_Paulo")
+	// Wednesday 2025-06-18 through Tuesday 2025-07-01
+	points := dailyPoints(loc, time.Date(2025, 6, 18, 0, 0, 0, 0, loc), 14)
+
+	tests := []struct {
+		name      string
+		weekStart time.Weekday
+		sizes     []int
+	}{
+		{"ISO weeks", time.Monday, []int{5, 7, 2}},
+		{"Sunday weeks", time.Sunday, []int{4, 7, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Analytics(points, "WEEK", loc, tt.weekStart)
+
+			if len(result) != len(tt.sizes) {
+				t.Fatalf("Expected %d buckets, got %d", len(tt.sizes), len(result))
+			}
+
+			totalSent := 0
+			for i, dp := range result {
+				if dp.Sent != tt.sizes[i]*10 || dp.Delivered != tt.sizes[i]*9 {
+					t.Errorf("Bucket %d: expected %d days, got sent %d delivered %d", i, tt.sizes[i], dp.Sent, dp.Delivered)
+				}
+				totalSent += dp.Sent
+			}
+
+			if totalSent != 140 {
+				t.Errorf("Expected rolled up total 140, got %d", totalSent)
+			}
+
+			if result[0].Start != points[0].Start || result[len(result)-1].End != points[len(points)-1].End {
+				t.Errorf("Expected buckets to cover exactly the input range")
+			}
+		})
+	}
+}
+
+func TestAnalytics_QuarterUsesTimezone(t *testing.T) {
+	loc, _ := time.LoadLocation("Asia/Tokyo")
+	// 2025-04-01 00:00 in Tokyo is still March 31 in UTC, so bucketing must use loc
+	points := dailyPoints(loc, time.Date(2025, 3, 30, 0, 0, 0, 0, loc), 3)
+
+	result := Analytics(points, "QUARTER", loc, time.Monday)
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 quarters, got %d", len(result))
+	}
+
+	if result[0].Sent != 20 || result[1].Sent != 10 {
+		t.Errorf("Expected 20 in Q1 and 10 in Q2, got %d and %d", result[0].Sent, result[1].Sent)
+	}
+}
+
+func TestTemplates_Month(t *testing.T) {
+	loc := time.UTC
+	day := func(d int) int64 { return time.Date(2025, 6, d, 0, 0, 0, 0, loc).Unix() }
+
+	points := []models.TemplateDataPoint{
+		{
+			TemplateID: "A", Start: day(29), End: day(30), Sent: 100, Delivered: 80, Read: 40,
+			Clicked: []models.ClickedAction{{Type: "url_button", ButtonContent: "Buy", Count: 4}},
+			Cost:    []models.CostMetric{{Type: "amount_spent", Value: 1.0}, {Type: "cost_per_delivered", Value: 0.0125}, {Type: "cost_per_read", Value: 0.025}},
+		},
+		{
+			TemplateID: "B", Start: day(29), End: day(30), Sent: 50, Delivered: 50, Read: 10,
+		},
+		{
+			TemplateID: "A", Start: day(30), End: day(30) + 86400, Sent: 100, Delivered: 120, Read: 60,
+			Clicked: []models.ClickedAction{{Type: "url_button", ButtonContent: "Buy", Count: 6}, {Type: "quick_reply_button", ButtonContent: "Stop", Count: 2}},
+			Cost: []models.CostMetric{{Type: "amount_spent", Value: 3.0}, {Type: "cost_per_delivered", Value: 0.025},
+				{Type: "cost_per_url_button_click", Value: 0.5}, {Type: "marketing_fee", Value: 0.2}},
+		},
+	}
+
+	result := Templates(points, "MONTH", loc, time.Monday)
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 template buckets, got %d", len(result))
+	}
+
+	a := result[0]
+	if a.TemplateID != "A" || a.Sent != 200 || a.Delivered != 200 || a.Read != 100 {
+		t.Errorf("Unexpected totals for template A: %+v", a)
+	}
+
+	if len(a.Clicked) != 2 || a.Clicked[0].Count != 10 || a.Clicked[1].Count != 2 {
+		t.Errorf("Expected merged clicks [10, 2], got %+v", a.Clicked)
+	}
+
+	costs := make(map[string]float64)
+	for _, cost := range a.Cost {
+		costs[cost.Type] = cost.Value
+	}
+
+	if costs["amount_spent"] != 4.0 {
+		t.Errorf("Expected amount_spent 4.0, got %v", costs["amount_spent"])
+	}
+
+	if costs["cost_per_delivered"] != 0.02 {
+		t.Errorf("Expected recomputed cost_per_delivered 0.02, got %v", costs["cost_per_delivered"])
+	}
+
+	if costs["cost_per_url_button_click"] != 0.4 {
+		t.Errorf("Expected recomputed cost_per_url_button_click 0.4, got %v", costs["cost_per_url_button_click"])
+	}
+
+	if costs["cost_per_read"] != 0.04 {
+		t.Errorf("Expected recomputed cost_per_read 0.04, got %v", costs["cost_per_read"])
+	}
+
+	if costs["marketing_fee"] != 0.2 {
+		t.Errorf("Expected unknown cost type averaged to 0.2, got %v", costs["marketing_fee"])
+	}
+
+	if _, ok := costs["cost_per_click"]; ok || len(a.Cost) != 5 {
+		t.Errorf("Expected only the reported cost types, got %+v", a.Cost)
+	}
+
+	// Input data must not be modified by the rollup
+	if points[0].Clicked[0].Count != 4 {
+		t.Errorf("Rollup modified its input: %+v", points[0].Clicked)
+	}
+}
+
+func TestSourceGranularity(t *testing.T) {
+	tests := []struct {
+		mode        string
+		granularity string
+		expected    string
+	}{
+		{"analytics", "HOUR", "HALF_HOUR"},
+		{"analytics", "WEEK", "DAY"},
+		{"analytics", "QUARTER", "DAY"},
+		{"analytics", "MONTH", "MONTH"},
+		{"template", "WEEK", "daily"},
+		{"template", "daily", "daily"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode+"_"+tt.granularity, func(t *testing.T) {
+			result := SourceGranularity(tt.mode, tt.granularity)
+			if result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
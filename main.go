@@ -3,34 +3,87 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 	_ "time/tzdata" // Embed timezone data for Windows compatibility
 
+	"golang.org/x/term"
+
 	"wppanalyticscli/internal/api"
 	"wppanalyticscli/internal/config"
 	"wppanalyticscli/internal/datetime"
+	"wppanalyticscli/internal/export"
 	"wppanalyticscli/internal/formatter"
+	"wppanalyticscli/internal/history"
 	"wppanalyticscli/internal/input"
 )
 
 func main() {
-	var wbaID = flag.String("wbaid", "", "WBA ID (required)")
-	var startDate = flag.String("start", "", "Start date in ISO-8601 format: YYYY-MM-DD or YYYY-MM-DDTHH:MM:SSZ (required)")
-	var endDate = flag.String("end", "", "End date in ISO-8601 format: YYYY-MM-DD or YYYY-MM-DDTHH:MM:SSZ (required)")
-	var granularity = flag.String("granularity", "DAY", "Granularity: HALF_HOUR, DAY, or MONTH (for analytics) / daily (for templates)")
+	if len(os.Args) > 1 && os.Args[1] == "token" {
+		os.Exit(runTokenCommand(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "cache" {
+		os.Exit(runCacheCommand(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "sync" {
+		os.Exit(runSyncCommand(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		os.Exit(runExportCommand(os.Args[2:]))
+	}
+
+	var wbaID = flag.String("wbaid", "", "WBA ID, or a comma-separated list for a multi-account report (required unless -profile)")
+	var profile = flag.String("profile", "", "Named list of WBA IDs from the profiles file")
+	var concurrency = flag.Int("concurrency", 4, "Accounts fetched at once in a multi-account report")
+	var startDate = flag.String("start", "", "Start date: YYYY-MM-DD, YYYY-MM-DDTHH:MM:SSZ or relative (today, yesterday, -7d, now-36h)")
+	var endDate = flag.String("end", "", "End date: YYYY-MM-DD, YYYY-MM-DDTHH:MM:SSZ or relative (today, yesterday, -7d, now-36h)")
+	var align = flag.Bool("align", false, "Snap -start/-end to granularity boundaries in -timezone")
+	var dateRange = flag.String("range", "", "Named date range instead of -start/-end: "+strings.Join(datetime.RangePresets, ", "))
+	var granularity = flag.String("granularity", "DAY", "Granularity: HALF_HOUR, HOUR, DAY, WEEK, MONTH, QUARTER, or YEAR (for analytics) / daily, WEEK, MONTH, QUARTER, or YEAR (for templates)")
+	var compare = flag.String("compare", "", "Compare with another window: previous-period, previous-year or <start>..<end>")
+	var weekStart = flag.String("week-start", "iso", "First day of WEEK buckets: iso (Monday) or a weekday name")
 	var timezone = flag.String("timezone", "America/Sao_Paulo", "Timezone for date display (default: America/Sao_Paulo)")
+	var inputTimezone = flag.String("input-timezone", "", "Timezone for date-only and naive -start/-end values (default: -timezone)")
 	
 	// Template analytics specific flags
 	var mode = flag.String("mode", "analytics", "Mode: analytics, template, or list-templates")
 	var metricTypes = flag.String("metrics", "", "Comma-separated metric types for templates (cost,clicked,delivered,read,sent)")
 	var templateIDs = flag.String("templates", "", "Comma-separated template IDs for template analytics")
+	var currency = flag.String("currency", "", "Currency for costs, e.g. USD or BRL (default: the account's currency)")
+	var numbers = flag.String("numbers", "short", "Number display: exact (1,234) or short (1.2K)")
+	var locale = flag.String("locale", "en-US", "Locale for thousands and decimal separators, e.g. pt-BR")
+	var view = flag.String("view", "table", "Template report view: table or funnel")
+	var output = flag.String("output", "text", "Output format: text, markdown, html or parquet")
+	var outFile = flag.String("out", "", "Destination file for -output=parquet")
+	var tableStyle = flag.String("table-style", "box", "Table borders: box or ascii (for logs and legacy consoles)")
+	var color = flag.String("color", "auto", "Color statuses and rates: auto (terminal without NO_COLOR), always or never")
+	var plain = flag.Bool("plain", false, "Plain text for logs: no emoji, no colors, ASCII tables and charts")
+	var formatTemplate = flag.String("format-template", "", "Custom output: a Go text/template file, or inline template text")
+	var chart = flag.Bool("chart", false, "Draw sparklines and bar charts under the tables, sized to the terminal")
+	var groupBy = flag.String("group-by", "", "Group template rows by template (default) or date, with subtotals")
+	var sortBy = flag.String("sort", "", "Sort table rows: date, sent or delivered (analytics); date, template, sent, delivered, read, clicks or cost (template); name or status (list-templates)")
 	
 	// Template listing specific flags
 	var limit = flag.Int("limit", 25, "Number of templates to retrieve (default: 25)")
 	var after = flag.String("after", "", "Pagination cursor for next page")
 	
+	var source = flag.String("source", "api", "Read reports from the Graph API (api) or from the history saved by sync (local)")
+	var historyPath = flag.String("db", "", "History database for -source=local (default: in the user config directory)")
+	var noCache = flag.Bool("no-cache", false, "Always call the API, without reading or writing the response cache")
+	var refresh = flag.Bool("refresh", false, "Call the API and replace cached responses")
+	var cacheTTL = flag.Duration("cache-ttl", defaultCacheTTL, "Cache lifetime for windows ending within the last 3 days; older windows are kept until cleared")
+	
+	var timeout = flag.Duration("timeout", 0, "Limit for the whole run, e.g. 2m (0 for no limit)")
+	var requestTimeout = flag.Duration("request-timeout", api.DefaultRequestTimeout, "Limit for each API request (0 for no limit)")
+	var apiBaseURL, apiVersion = apiEndpointFlags(flag.CommandLine)
+	
+	var debug = flag.Bool("debug", false, "Print API requests to stderr (access token is redacted)")
+	var preflight = flag.Bool("preflight", false, "Check the access token with debug_token before running")
+	var tokenWarnDays = flag.Int("token-warn-days", config.DefaultTokenWarnDays, "Warn when the token expires within this many days (with -preflight)")
+	
 	flag.Parse()
 
 	// Parse template-specific parameters
@@ -53,18 +106,66 @@ func main() {
 		}
 	}
 
+	// Resolve the accounts from -wbaid or -profile
+	accounts := config.ParseAccounts(*wbaID)
+	if *profile != "" {
+		if len(accounts) > 0 {
+			fmt.Fprintf(os.Stderr, "Error: use either -wbaid or -profile\n")
+			os.Exit(1)
+		}
+		profilesPath, err := config.DefaultProfilesPath()
+		if err == nil {
+			accounts, err = config.LoadProfile(profilesPath, *profile)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	primaryAccount := ""
+	if len(accounts) > 0 {
+		primaryAccount = accounts[0]
+	}
+
 	// Create configuration
 	cfg := &config.Config{
-		WBAID:       *wbaID,
-		StartDate:   *startDate,
-		EndDate:     *endDate,
-		Granularity: *granularity,
-		Timezone:    *timezone,
-		Mode:        *mode,
-		MetricTypes: metricTypesList,
-		TemplateIDs: templateIDsList,
-		Limit:       *limit,
-		After:       *after,
+		WBAID:         primaryAccount,
+		Accounts:      accounts,
+		Concurrency:   *concurrency,
+		StartDate:     *startDate,
+		EndDate:       *endDate,
+		Range:         *dateRange,
+		Granularity:   *granularity,
+		Timezone:      *timezone,
+		InputTimezone: *inputTimezone,
+		Align:         *align,
+		WeekStart:     *weekStart,
+		Compare:       *compare,
+		View:          *view,
+		Output:        *output,
+		OutFile:       *outFile,
+		Chart:         *chart,
+		TableStyle:    *tableStyle,
+		Color:         *color,
+		Plain:         *plain,
+		CustomFormat:  *formatTemplate,
+		GroupBy:       *groupBy,
+		Sort:          *sortBy,
+		Currency:      *currency,
+		Numbers:       *numbers,
+		Locale:        *locale,
+		NoCache:       *noCache,
+		Refresh:       *refresh,
+		CacheTTL:      *cacheTTL,
+		Timeout:       *timeout,
+		ReqTimeout:    *requestTimeout,
+		Source:        *source,
+		HistoryPath:   *historyPath,
+		Mode:          *mode,
+		MetricTypes:   metricTypesList,
+		TemplateIDs:   templateIDsList,
+		Limit:         *limit,
+		After:         *after,
 	}
 
 	// Basic parameter validation
@@ -76,26 +177,43 @@ func main() {
 		fmt.Fprintf(os.Stderr, "  %s -mode=template -wbaid=123 -start=2025-06-20 -end=2025-06-24 -templates=1026573095658757 -metrics=cost,clicked,delivered,read,sent\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "\nList Templates:\n")
 		fmt.Fprintf(os.Stderr, "  %s -mode=list-templates -wbaid=123\n", os.Args[0])
-		fmt.Fprintf(os.Stderr, "\nDate formats: YYYY-MM-DD or YYYY-MM-DDTHH:MM:SSZ\n")
+		fmt.Fprintf(os.Stderr, "\nMultiple Accounts:\n")
+		fmt.Fprintf(os.Stderr, "  %s -wbaid=123,456 -start=2024-01-01 -end=2024-01-31 [-concurrency=4]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -profile=brands -start=2024-01-01 -end=2024-01-31\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "\nToken Info:\n")
+		fmt.Fprintf(os.Stderr, "  %s token info [-wbaid=123] [-warn-days=7]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "\nLocal History:\n")
+		fmt.Fprintf(os.Stderr, "  %s sync -wbaid=123 [-since=-90d] [-trailing=3] [-templates=...]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -wbaid=123 -source=local -start=2024-01-01 -end=2025-01-01 -granularity=MONTH\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "\nSQLite Export:\n")
+		fmt.Fprintf(os.Stderr, "  %s export sqlite -db=messaging.db -wbaid=123 [-start=-30d] [-end=today] [-templates=...]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "\nResponse Cache:\n")
+		fmt.Fprintf(os.Stderr, "  %s cache stats|clear\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "\nDate formats: YYYY-MM-DD, YYYY-MM-DDTHH:MM:SSZ or relative (today, yesterday, -7d, -2w, now-36h)\n")
+		fmt.Fprintf(os.Stderr, "Date ranges: -range=%s\n", strings.Join(datetime.RangePresets, "|"))
 		os.Exit(1)
 	}
 
 	// Load timezone with fallback
-	loc, err := time.LoadLocation(cfg.Timezone)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: Could not load timezone '%s': %v\n", cfg.Timezone, err)
-		fmt.Fprintf(os.Stderr, "Falling back to UTC timezone\n")
-		loc = time.UTC
-	}
+	loc := loadLocation(cfg.Timezone)
 
-	// Load access token
-	prompter := input.NewSecurePrompter()
-	accessToken, err := config.LoadAccessToken(prompter.PromptForToken)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading access token: %v\n", err)
-		os.Exit(1)
+	// Load access token; the local history does not need one
+	if cfg.Source != "local" {
+		accessToken, err := loadAccessToken()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading access token: %v\n", err)
+			os.Exit(1)
+		}
+		cfg.AccessToken = accessToken
+		cfg.AppSecret = config.LoadAppSecret()
+
+		baseURL, version, err := resolveAPIEndpoint(*apiBaseURL, *apiVersion)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		cfg.APIBaseURL, cfg.APIVersion = baseURL, version
 	}
-	cfg.AccessToken = accessToken
 
 	// Validate configuration
 	validator := config.NewConfigValidator()
@@ -104,10 +222,46 @@ func main() {
 		os.Exit(1)
 	}
 
+	if *preflight && cfg.Source == "local" {
+		fmt.Fprintf(os.Stderr, "Error: preflight checks the token with the API and is not available with -source=local\n")
+		os.Exit(1)
+	}
+
+	numberFormat, err := formatter.NewNumberFormat(cfg.Numbers, cfg.Locale)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Parse the custom output template before calling the API
+	var customFormatter *formatter.CustomFormatter
+	if cfg.CustomFormat != "" {
+		customFormatter, err = newCustomFormatter(cfg.CustomFormat)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		customFormatter.SetNumbers(numberFormat)
+		customFormatter.SetSort(cfg.Sort)
+	}
+
+	// Dates without an explicit offset are interpreted in the input timezone
+	inputLoc := loc
+	if cfg.InputTimezone != "" {
+		inputLoc = loadLocation(cfg.InputTimezone)
+	}
+
 	// Parse dates (only for analytics and template modes)
 	var startEpoch, endEpoch int64
-	if cfg.Mode != "list-templates" {
-		parser := datetime.NewISO8601Parser()
+	if cfg.Mode != "list-templates" && cfg.Range != "" {
+		rangeStart, rangeEnd, err := datetime.ResolveRange(cfg.Range, time.Now().In(inputLoc))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error resolving range: %v\n", err)
+			os.Exit(1)
+		}
+		startEpoch, endEpoch = rangeStart.Unix(), rangeEnd.Unix()
+	} else if cfg.Mode != "list-templates" {
+		parser := datetime.NewISO8601ParserInLocation(inputLoc)
 		var err error
 		startEpoch, err = parser.ParseToEpoch(cfg.StartDate)
 		if err != nil {
@@ -122,46 +276,329 @@ func main() {
 		}
 	}
 
-	// Create API client
-	apiClient := api.NewFacebookGraphClient()
+	// Check the window against the granularity, snapping it when -align is set
+	if cfg.Mode != "list-templates" {
+		check, err := validator.ValidateWindow(cfg, time.Unix(startEpoch, 0).In(loc), time.Unix(endEpoch, 0).In(loc))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		for _, note := range check.Notes {
+			fmt.Fprintf(os.Stderr, "Note: %s\n", note)
+		}
+		startEpoch, endEpoch = check.Start.Unix(), check.End.Unix()
+	}
+
+	// Ctrl-C, SIGTERM and -timeout cancel in-flight requests from here on
+	ctx, cancel := runContext(cfg.Timeout)
+	defer cancel()
+	
+	// Create API client, serving repeated requests from the response cache, or
+	// read the history saved by sync
+	var apiClient api.Client
+	if cfg.Source == "local" {
+		store, err := openHistory(cfg.HistoryPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer store.Close()
+		apiClient = history.NewClient(store, loc)
+	} else {
+		apiClient = newAPIClient(cfg.AppSecret, *debug, cfg.ReqTimeout, cfg.APIBaseURL, cfg.APIVersion)
+		if !cfg.NoCache {
+			apiClient = newCachedClient(apiClient, cfg, loc, *debug)
+		}
+	}
+	if *preflight {
+		if err := runTokenPreflight(ctx, apiClient, cfg, *tokenWarnDays); err != nil {
+			os.Exit(failureStatus(ctx, "Error: token preflight failed", err))
+		}
+	}
+	
+	// Resolve the comparison window in the same way as -start/-end
+	var compareStart, compareEnd int64
+	if cfg.Compare != "" {
+		parser := datetime.NewISO8601ParserInLocation(inputLoc)
+		start, end, err := datetime.ResolveComparison(cfg.Compare, time.Unix(startEpoch, 0).In(loc), time.Unix(endEpoch, 0).In(loc), parser)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		compareStart, compareEnd = start.Unix(), end.Unix()
+	}
 	
 	// Handle different modes
-	if cfg.Mode == "list-templates" {
+	if len(cfg.Accounts) > 1 {
+		os.Exit(runAccountsReport(ctx, apiClient, cfg, numberFormat, startEpoch, endEpoch, loc))
+	} else if cfg.Compare != "" {
+		comparisonFormatter := formatter.NewComparisonFormatter()
+		comparisonFormatter.SetWindow(startEpoch, endEpoch)
+		comparisonFormatter.SetPreviousWindow(compareStart, compareEnd)
+		applyTextOptions(comparisonFormatter, cfg, numberFormat)
+		
+		if cfg.Mode == "template" {
+			comparisonFormatter.SetCurrency(resolveCurrency(ctx, apiClient, cfg))
+			current, err := fetchTemplateAnalytics(ctx, apiClient, cfg, startEpoch, endEpoch, loc)
+			if err != nil {
+				os.Exit(failureStatus(ctx, "Error making template request", err))
+			}
+			previous, err := fetchTemplateAnalytics(ctx, apiClient, cfg, compareStart, compareEnd, loc)
+			if err != nil {
+				os.Exit(failureStatus(ctx, "Error making comparison template request", err))
+			}
+			printReport(cfg, comparisonFormatter.FormatTemplate(current, previous, loc))
+		} else {
+			current, err := fetchAnalytics(ctx, apiClient, cfg, startEpoch, endEpoch, loc)
+			if err != nil {
+				os.Exit(failureStatus(ctx, "Error making request", err))
+			}
+			previous, err := fetchAnalytics(ctx, apiClient, cfg, compareStart, compareEnd, loc)
+			if err != nil {
+				os.Exit(failureStatus(ctx, "Error making comparison request", err))
+			}
+			printReport(cfg, comparisonFormatter.FormatAnalytics(current, previous, loc))
+		}
+	} else if cfg.Mode == "list-templates" {
 		// Make template list request
-		listResponse, err := apiClient.ListTemplates(cfg.WBAID, cfg.AccessToken, cfg.Limit, cfg.After)
+		listResponse, err := apiClient.ListTemplates(ctx, cfg.WBAID, cfg.AccessToken, cfg.Limit, cfg.After)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error listing templates: %v\n", err)
-			os.Exit(1)
+			os.Exit(failureStatus(ctx, "Error listing templates", err))
 		}
 
 		// Format and display list output
-		listFormatter := formatter.NewListFormatter()
-		result := listFormatter.FormatList(listResponse)
-		fmt.Print(result)
+		if customFormatter != nil {
+			printReport(cfg, customReport(customFormatter.FormatList(listResponse)))
+		} else if documentFormatter := newDocumentFormatter(cfg.Output); documentFormatter != nil {
+			documentFormatter.SetSort(cfg.Sort)
+			documentFormatter.SetNumbers(numberFormat)
+			printReport(cfg, documentFormatter.FormatList(listResponse))
+		} else {
+			listFormatter := formatter.NewListFormatter()
+			listFormatter.SetSort(cfg.Sort)
+			applyTextOptions(listFormatter, cfg, numberFormat)
+			result := listFormatter.FormatList(listResponse)
+			printReport(cfg, result)
+		}
 	} else if cfg.Mode == "template" {
 		// Make template analytics request
-		templateResponse, err := apiClient.GetTemplateAnalytics(cfg.WBAID, startEpoch, endEpoch, cfg.Granularity, cfg.MetricTypes, cfg.TemplateIDs, cfg.AccessToken)
+		templateResponse, err := fetchTemplateAnalytics(ctx, apiClient, cfg, startEpoch, endEpoch, loc)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error making template request: %v\n", err)
-			os.Exit(1)
+			os.Exit(failureStatus(ctx, "Error making template request", err))
+		}
+		currency := resolveCurrency(ctx, apiClient, cfg)
+		if err := ctx.Err(); err != nil {
+			os.Exit(failureStatus(ctx, "Error", err))
 		}
 
 		// Format and display template output
-		templateFormatter := formatter.NewTemplateFormatter()
-		result := templateFormatter.FormatTemplate(templateResponse, loc)
-		fmt.Print(result)
+		if cfg.Output == "parquet" {
+			writeParquetFile(cfg, func(w io.Writer) (int, error) {
+				return export.WriteTemplateParquet(w, cfg.WBAID, templateResponse)
+			})
+		} else if customFormatter != nil {
+			customFormatter.SetWindow(startEpoch, endEpoch)
+			customFormatter.SetCurrency(currency)
+			printReport(cfg, customReport(customFormatter.FormatTemplate(templateResponse, loc)))
+		} else if documentFormatter := newDocumentFormatter(cfg.Output); documentFormatter != nil {
+			documentFormatter.SetWindow(startEpoch, endEpoch)
+			documentFormatter.SetGroupBy(cfg.GroupBy)
+			documentFormatter.SetSort(cfg.Sort)
+			documentFormatter.SetCurrency(currency)
+			documentFormatter.SetNumbers(numberFormat)
+			printReport(cfg, documentFormatter.FormatTemplate(templateResponse, loc))
+		} else if cfg.View == "funnel" {
+			printReport(cfg, textFunnelReport(cfg, numberFormat, templateResponse, startEpoch, endEpoch, loc))
+		} else {
+			printReport(cfg, textTemplateReport(cfg, numberFormat, currency, templateResponse, startEpoch, endEpoch, loc))
+		}
 	} else {
 		// Make regular analytics request
-		response, err := apiClient.GetAnalytics(cfg.WBAID, startEpoch, endEpoch, cfg.Granularity, cfg.AccessToken)
+		response, err := fetchAnalytics(ctx, apiClient, cfg, startEpoch, endEpoch, loc)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error making request: %v\n", err)
-			os.Exit(1)
+			os.Exit(failureStatus(ctx, "Error making request", err))
 		}
 
 		// Format and display output
-		outputFormatter := formatter.NewTableFormatter()
-		result := outputFormatter.Format(response, loc)
-		fmt.Print(result)
+		if cfg.Output == "parquet" {
+			writeParquetFile(cfg, func(w io.Writer) (int, error) {
+				return export.WriteAnalyticsParquet(w, response)
+			})
+		} else if customFormatter != nil {
+			customFormatter.SetWindow(startEpoch, endEpoch)
+			printReport(cfg, customReport(customFormatter.Format(response, loc)))
+		} else if documentFormatter := newDocumentFormatter(cfg.Output); documentFormatter != nil {
+			documentFormatter.SetWindow(startEpoch, endEpoch)
+			documentFormatter.SetSort(cfg.Sort)
+			documentFormatter.SetNumbers(numberFormat)
+			printReport(cfg, documentFormatter.Format(response, loc))
+		} else {
+			printReport(cfg, textAnalyticsReport(cfg, numberFormat, response, startEpoch, endEpoch, loc))
+		}
+	}
+}
+
+// newDocumentFormatter returns the markdown or HTML formatter for -output, or nil for text
+func newDocumentFormatter(output string) *formatter.DocumentFormatter {
+	switch output {
+	case "markdown":
+		return formatter.NewMarkdownFormatter()
+	case "html":
+		return formatter.NewHTMLFormatter()
+	default:
+		return nil
+	}
+}
+
+// newCustomFormatter parses -format-template, read from a file when it names one
+// and used as inline template text otherwise
+func newCustomFormatter(value string) (*formatter.CustomFormatter, error) {
+	text := value
+	if info, err := os.Stat(value); err == nil && !info.IsDir() {
+		data, err := os.ReadFile(value)
+		if err != nil {
+			return nil, fmt.Errorf("reading format template: %w", err)
+		}
+		text = string(data)
+	}
+	return formatter.NewCustomFormatter(text)
+}
+
+// customReport returns a custom template report, exiting when the template fails
+func customReport(report string, err error) string {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
+	return report
 }
 
+// textOptions are the display settings shared by the text formatters
+type textOptions interface {
+	SetNumbers(format formatter.NumberFormat)
+	SetTableStyle(style string)
+	SetWidth(width int)
+	SetColor(enabled bool)
+}
+
+// applyTextOptions applies number format, table style, colors and, on a terminal, its width
+func applyTextOptions(f textOptions, cfg *config.Config, numberFormat formatter.NumberFormat) {
+	f.SetNumbers(numberFormat)
+	f.SetWidth(stdoutWidth())
+	if cfg.Plain {
+		f.SetTableStyle("ascii")
+		f.SetColor(false)
+		return
+	}
+	f.SetTableStyle(cfg.TableStyle)
+	f.SetColor(colorEnabled(cfg.Color))
+}
+
+// colorEnabled reports whether to color output for -color: auto colors only a
+// terminal, and never when NO_COLOR is set or TERM is dumb
+func colorEnabled(mode string) bool {
+	switch mode {
+	case "always":
+		return true
+	case "never":
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// printReport writes a report to stdout, stripping emoji in -plain mode
+func printReport(cfg *config.Config, report string) {
+	if cfg.Plain {
+		report = formatter.Plain(report)
+	}
+	fmt.Print(report)
+}
+
+// stdoutWidth returns the width of the terminal on stdout, or 0 when output is redirected
+func stdoutWidth() int {
+	if width, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && width > 0 {
+		return width
+	}
+	return 0
+}
+
+// terminalWidth returns the width of the terminal on stdout, falling back to
+// $COLUMNS and then 80 columns when stdout is not a terminal
+func terminalWidth() int {
+	if width := stdoutWidth(); width > 0 {
+		return width
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return 80
+}
+
+// loadLocation loads a timezone, falling back to UTC with a warning
+func loadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Could not load timezone '%s': %v\n", name, err)
+		fmt.Fprintf(os.Stderr, "Falling back to UTC timezone\n")
+		return time.UTC
+	}
+	return loc
+}
+
+// loadAccessToken reads the token from the environment or prompts for it
+func loadAccessToken() (string, error) {
+	prompter := input.NewSecurePrompter()
+	return config.LoadAccessToken(prompter.PromptForToken)
+}
+
+// newAPIClient creates the Graph API client for baseURL and version with optional
+// request signing and debug output, limiting each request to requestTimeout
+func newAPIClient(appSecret string, debug bool, requestTimeout time.Duration, baseURL, version string) *api.FacebookGraphClient {
+	opts := []api.Option{
+		api.WithBaseURL(baseURL),
+		api.WithVersion(version),
+		api.WithRequestTimeout(requestTimeout),
+		api.WithAppSecret(appSecret),
+	}
+	if debug {
+		opts = append(opts, api.WithDebugOutput(os.Stderr))
+	}
+	return api.NewFacebookGraphClient(opts...)
+}
+
+// apiEndpointFlags registers -api-base-url and -api-version, which fall back to
+// FB_API_BASE_URL, FB_API_VERSION, the settings file and then the defaults
+func apiEndpointFlags(flags *flag.FlagSet) (baseURL, version *string) {
+	baseURL = flags.String("api-base-url", "", "Graph API host, e.g. a local mock (default: FB_API_BASE_URL, the settings file or "+api.DefaultBaseURL+")")
+	version = flags.String("api-version", "", "Graph API version (default: FB_API_VERSION, the settings file or "+api.DefaultVersion+")")
+	return baseURL, version
+}
+
+// resolveAPIEndpoint fills the endpoint flags from the environment, the settings
+// file and the defaults, validates them and warns when the version is about to be
+// retired
+func resolveAPIEndpoint(baseURL, version string) (string, string, error) {
+	// Without a user config directory only the flags and the environment apply
+	settingsPath, _ := config.DefaultSettingsPath()
+	baseURL, version, err := config.LoadAPIEndpoint(baseURL, version, settingsPath)
+	if err != nil {
+		return "", "", err
+	}
+	if baseURL == "" {
+		baseURL = api.DefaultBaseURL
+	}
+	if version == "" {
+		version = api.DefaultVersion
+	}
+
+	if err := api.ValidateEndpoint(baseURL, version); err != nil {
+		return "", "", err
+	}
+	if warning := api.SunsetWarning(version, time.Now()); warning != "" {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+	return baseURL, version, nil
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"wppanalyticscli/internal/api"
+	"wppanalyticscli/internal/config"
+	"wppanalyticscli/internal/datetime"
+	"wppanalyticscli/internal/formatter"
+	"wppanalyticscli/internal/models"
+	"wppanalyticscli/internal/rollup"
+)
+
+// fetchAnalytics requests analytics for [start, end) and re-buckets finer API
+// data locally for HOUR, WEEK, QUARTER and YEAR
+func fetchAnalytics(ctx context.Context, apiClient api.Client, cfg *config.Config, start, end int64, loc *time.Location) (*models.AnalyticsResponse, error) {
+	response, err := apiClient.GetAnalytics(ctx, cfg.WBAID, start, end, rollup.SourceGranularity(cfg.Mode, cfg.Granularity), cfg.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if rollup.IsClientSide(cfg.Granularity) {
+		weekStart, _ := datetime.ParseWeekStart(cfg.WeekStart)
+		response.Analytics.DataPoints = rollup.Analytics(response.Analytics.DataPoints, cfg.Granularity, loc, weekStart)
+		response.Analytics.Granularity = cfg.Granularity
+	}
+
+	return response, nil
+}
+
+// fetchTemplateAnalytics requests template analytics for [start, end), in
+// several requests when the window is longer than the endpoint accepts, and
+// re-buckets the daily data locally for coarser granularities
+func fetchTemplateAnalytics(ctx context.Context, apiClient api.Client, cfg *config.Config, start, end int64, loc *time.Location) (*models.TemplateAnalyticsResponse, error) {
+	response := &models.TemplateAnalyticsResponse{}
+	for _, chunk := range config.TemplateChunks(time.Unix(start, 0).In(loc), time.Unix(end, 0).In(loc)) {
+		chunkResponse, err := apiClient.GetTemplateAnalytics(ctx, cfg.WBAID, chunk[0].Unix(), chunk[1].Unix(), rollup.SourceGranularity(cfg.Mode, cfg.Granularity), cfg.MetricTypes, cfg.TemplateIDs, cfg.AccessToken)
+		if err != nil {
+			return nil, err
+		}
+		mergeTemplateResponse(response, chunkResponse)
+	}
+
+	if rollup.IsTemplateClientSide(cfg.Granularity) {
+		weekStart, _ := datetime.ParseWeekStart(cfg.WeekStart)
+		for i := range response.Data {
+			response.Data[i].DataPoints = rollup.Templates(response.Data[i].DataPoints, cfg.Granularity, loc, weekStart)
+			response.Data[i].Granularity = cfg.Granularity
+		}
+	}
+
+	return response, nil
+}
+
+// mergeTemplateResponse appends the data points of a later chunk to response,
+// entry by entry
+func mergeTemplateResponse(response, chunk *models.TemplateAnalyticsResponse) {
+	for i, data := range chunk.Data {
+		if i < len(response.Data) {
+			response.Data[i].DataPoints = append(response.Data[i].DataPoints, data.DataPoints...)
+		} else {
+			response.Data = append(response.Data, data)
+		}
+	}
+	if chunk.Paging != nil {
+		response.Paging = chunk.Paging
+	}
+}
+
+// resolveCurrency returns the configured currency, or the WABA's billing
+// currency when none was given, falling back to USD if it cannot be fetched.
+// The account is only queried when cost metrics were requested.
+func resolveCurrency(ctx context.Context, apiClient api.Client, cfg *config.Config) string {
+	if cfg.Currency != "" {
+		return cfg.Currency
+	}
+
+	requestsCost := false
+	for _, metric := range cfg.MetricTypes {
+		if strings.EqualFold(metric, "cost") {
+			requestsCost = true
+		}
+	}
+	if !requestsCost {
+		return "USD"
+	}
+
+	account, err := apiClient.GetBusinessAccount(ctx, cfg.WBAID, cfg.AccessToken)
+	if err != nil && ctx.Err() != nil {
+		// The run is stopping; the caller reports the cancellation
+		return "USD"
+	}
+	if err != nil || account.Currency == "" {
+		fmt.Fprintf(os.Stderr, "Warning: Could not determine account currency, showing costs in USD (use -currency to set it)\n")
+		return "USD"
+	}
+
+	return account.Currency
+}
+
+// textAnalyticsReport formats analytics as the text table report
+func textAnalyticsReport(cfg *config.Config, numberFormat formatter.NumberFormat, response *models.AnalyticsResponse, start, end int64, loc *time.Location) string {
+	outputFormatter := formatter.NewTableFormatter()
+	outputFormatter.SetWindow(start, end)
+	outputFormatter.SetSort(cfg.Sort)
+	applyTextOptions(outputFormatter, cfg, numberFormat)
+	if cfg.Chart {
+		outputFormatter.SetChart(terminalWidth())
+	}
+	return outputFormatter.Format(response, loc)
+}
+
+// textTemplateReport formats template analytics as the text table report
+func textTemplateReport(cfg *config.Config, numberFormat formatter.NumberFormat, currency string, response *models.TemplateAnalyticsResponse, start, end int64, loc *time.Location) string {
+	templateFormatter := formatter.NewTemplateFormatter()
+	templateFormatter.SetWindow(start, end)
+	templateFormatter.SetGroupBy(cfg.GroupBy)
+	templateFormatter.SetSort(cfg.Sort)
+	templateFormatter.SetCurrency(currency)
+	applyTextOptions(templateFormatter, cfg, numberFormat)
+	if cfg.Chart {
+		templateFormatter.SetChart(terminalWidth())
+	}
+	return templateFormatter.FormatTemplate(response, loc)
+}
+
+// textFunnelReport formats template analytics as the text funnel report
+func textFunnelReport(cfg *config.Config, numberFormat formatter.NumberFormat, response *models.TemplateAnalyticsResponse, start, end int64, loc *time.Location) string {
+	funnelFormatter := formatter.NewFunnelFormatter()
+	funnelFormatter.SetWindow(start, end)
+	applyTextOptions(funnelFormatter, cfg, numberFormat)
+	return funnelFormatter.FormatFunnel(response, loc)
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// Causes of an early stop, reported by context.Cause
+var (
+	errInterrupted = errors.New("interrupted")
+	errRunTimeout  = errors.New("run timed out")
+)
+
+// Exit statuses of runs stopped early, following shell conventions
+const (
+	exitInterrupted = 130
+	exitTimedOut    = 124
+)
+
+// runContext returns a context cancelled on SIGINT or SIGTERM and, when timeout
+// is positive, once it elapses. After the first signal the default handling is
+// restored, so a second Ctrl-C exits immediately.
+func runContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case <-signals:
+			signal.Stop(signals)
+			cancel(errInterrupted)
+		case <-ctx.Done():
+		}
+	}()
+
+	stop := func() {
+		signal.Stop(signals)
+		cancel(context.Canceled)
+	}
+	if timeout <= 0 {
+		return ctx, stop
+	}
+
+	timeoutCtx, cancelTimeout := context.WithTimeoutCause(ctx, timeout, errRunTimeout)
+	return timeoutCtx, func() {
+		cancelTimeout()
+		stop()
+	}
+}
+
+// failureStatus prints why a run failed and returns its exit status: 130 when
+// interrupted, 124 when -timeout ran out, otherwise 1 with message and err
+func failureStatus(ctx context.Context, message string, err error) int {
+	switch context.Cause(ctx) {
+	case errInterrupted:
+		fmt.Fprintf(os.Stderr, "Interrupted: in-flight requests were cancelled\n")
+		return exitInterrupted
+	case errRunTimeout:
+		fmt.Fprintf(os.Stderr, "Error: run timed out (-timeout): in-flight requests were cancelled\n")
+		return exitTimedOut
+	}
+	fmt.Fprintf(os.Stderr, "%s: %v\n", message, err)
+	return 1
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"wppanalyticscli/internal/api"
+	"wppanalyticscli/internal/config"
+	"wppanalyticscli/internal/datetime"
+	"wppanalyticscli/internal/history"
+)
+
+// defaultTrailingDays is how many recent days every sync fetches again, since
+// delivered and read counts keep changing for a few days
+const defaultTrailingDays = 3
+
+// runSyncCommand handles "sync" and returns the process exit code
+func runSyncCommand(args []string) int {
+	flags := flag.NewFlagSet("sync", flag.ExitOnError)
+	var wbaID = flags.String("wbaid", "", "WBA ID (required)")
+	var since = flags.String("since", "-90d", "First day to backfill: YYYY-MM-DD or relative (-90d, -1y)")
+	var trailing = flags.Int("trailing", defaultTrailingDays, "Recent days fetched again on every sync")
+	var templateIDs = flags.String("templates", "", "Comma-separated template IDs to sync (default: every template of the WABA)")
+	var timezone = flags.String("timezone", "America/Sao_Paulo", "Timezone of day boundaries")
+	var historyPath = flags.String("db", "", "History database (default: in the user config directory)")
+	var debug = flags.Bool("debug", false, "Print API requests to stderr (access token is redacted)")
+	var timeout = flags.Duration("timeout", 0, "Limit for the whole run, e.g. 10m (0 for no limit)")
+	var requestTimeout = flags.Duration("request-timeout", api.DefaultRequestTimeout, "Limit for each API request (0 for no limit)")
+	var apiBaseURL, apiVersion = apiEndpointFlags(flags)
+	flags.Parse(args)
+
+	if *wbaID == "" {
+		fmt.Fprintf(os.Stderr, "Usage: %s sync -wbaid=<id> [-since=-90d] [-trailing=3] [-templates=<ids>] [-db=<path>]\n", os.Args[0])
+		return 1
+	}
+	if *trailing < 0 {
+		fmt.Fprintf(os.Stderr, "Error: trailing cannot be negative\n")
+		return 1
+	}
+
+	baseURL, version, err := resolveAPIEndpoint(*apiBaseURL, *apiVersion)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	loc := loadLocation(*timezone)
+	sinceEpoch, err := datetime.NewISO8601ParserInLocation(loc).ParseToEpoch(*since)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing since: %v\n", err)
+		return 1
+	}
+	sinceTime := time.Unix(sinceEpoch, 0).In(loc)
+	if oldest := time.Now().Add(-config.MaxLookback); sinceTime.Before(oldest) {
+		fmt.Fprintf(os.Stderr, "Note: since moved to %s, the oldest day the API retains\n", oldest.In(loc).Format("2006-01-02"))
+		sinceTime = oldest
+	}
+
+	accessToken, err := loadAccessToken()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading access token: %v\n", err)
+		return 1
+	}
+
+	store, err := openHistory(*historyPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	defer store.Close()
+
+	opts := history.SyncOptions{
+		WBAID:       *wbaID,
+		AccessToken: accessToken,
+		Since:       sinceTime,
+		Trailing:    *trailing,
+		Location:    loc,
+		TemplateIDs: splitList(*templateIDs),
+	}
+
+	ctx, cancel := runContext(*timeout)
+	defer cancel()
+
+	syncer := history.NewSyncer(newAPIClient(config.LoadAppSecret(), *debug, *requestTimeout, baseURL, version), store)
+	syncer.SetProgress(os.Stderr)
+	result, err := syncer.Sync(ctx, opts)
+	if err != nil {
+		return failureStatus(ctx, "Error", err)
+	}
+
+	fmt.Printf("🔄 Synced WhatsApp Business Account %s\n", *wbaID)
+	fmt.Printf("📊 Analytics days fetched: %d\n", result.AnalyticsDays)
+	fmt.Printf("📋 Template days fetched: %d across %d templates\n", result.TemplateDays, result.Templates)
+	fmt.Printf("🌐 API requests: %d\n", result.Requests)
+	if last, ok, err := store.LastSynced(*wbaID, "analytics"); err == nil && ok {
+		fmt.Printf("📅 Last synced day: %s\n", time.Unix(last, 0).In(loc).Format("2006-01-02"))
+	}
+	return 0
+}
+
+// openHistory opens the history database at path, or at the default location
+func openHistory(path string) (*history.Store, error) {
+	if path == "" {
+		defaultPath, err := history.DefaultPath()
+		if err != nil {
+			return nil, err
+		}
+		path = defaultPath
+	}
+	return history.Open(path)
+}
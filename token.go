@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"wppanalyticscli/internal/api"
+	"wppanalyticscli/internal/config"
+	"wppanalyticscli/internal/formatter"
+)
+
+// runTokenCommand handles "token <subcommand>" and returns the process exit code
+func runTokenCommand(args []string) int {
+	if len(args) == 0 || args[0] != "info" {
+		fmt.Fprintf(os.Stderr, "Usage: %s token info [-wbaid=<id>] [-warn-days=7] [-timezone=America/Sao_Paulo]\n", os.Args[0])
+		return 1
+	}
+
+	flags := flag.NewFlagSet("token info", flag.ExitOnError)
+	var wbaID = flags.String("wbaid", "", "WBA ID to check granular scopes against (optional)")
+	var warnDays = flags.Int("warn-days", config.DefaultTokenWarnDays, "Warn when the token expires within this many days")
+	var timezone = flags.String("timezone", "America/Sao_Paulo", "Timezone for date display")
+	var debug = flags.Bool("debug", false, "Print API requests to stderr (access token is redacted)")
+	var requestTimeout = flags.Duration("request-timeout", api.DefaultRequestTimeout, "Limit for each API request (0 for no limit)")
+	var apiBaseURL, apiVersion = apiEndpointFlags(flags)
+	flags.Parse(args[1:])
+
+	baseURL, version, err := resolveAPIEndpoint(*apiBaseURL, *apiVersion)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	loc := loadLocation(*timezone)
+
+	accessToken, err := loadAccessToken()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading access token: %v\n", err)
+		return 1
+	}
+
+	ctx, cancel := runContext(0)
+	defer cancel()
+
+	apiClient := newAPIClient(config.LoadAppSecret(), *debug, *requestTimeout, baseURL, version)
+	response, err := apiClient.DebugToken(ctx, accessToken)
+	if err != nil {
+		return failureStatus(ctx, "Error inspecting access token", err)
+	}
+
+	warnings, checkErr := config.CheckToken(&response.Data, *wbaID, time.Now(), *warnDays)
+	if checkErr != nil {
+		warnings = append(warnings, checkErr.Error())
+	}
+
+	tokenFormatter := formatter.NewTokenFormatter()
+	fmt.Print(tokenFormatter.FormatTokenInfo(&response.Data, loc, warnings))
+
+	if checkErr != nil {
+		return 1
+	}
+	return 0
+}
+
+// runTokenPreflight checks the token before a report runs, printing warnings to stderr
+func runTokenPreflight(ctx context.Context, apiClient api.Client, cfg *config.Config, warnDays int) error {
+	response, err := apiClient.DebugToken(ctx, cfg.AccessToken)
+	if err != nil {
+		return fmt.Errorf("could not inspect access token: %w", err)
+	}
+
+	// Granular scopes are checked for every account of a multi-account report
+	accounts := cfg.Accounts
+	if len(accounts) == 0 {
+		accounts = []string{cfg.WBAID}
+	}
+
+	seen := make(map[string]bool)
+	for _, wbaID := range accounts {
+		warnings, err := config.CheckToken(&response.Data, wbaID, time.Now(), warnDays)
+		if err != nil {
+			return err
+		}
+		for _, warning := range warnings {
+			if !seen[warning] {
+				seen[warning] = true
+				fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+			}
+		}
+	}
+	return nil
+}